@@ -0,0 +1,174 @@
+package csv
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// Compression formats of sources, which are detected by FromFile and Remote.
+const (
+	Gzip  = "gzip"
+	Zip   = "zip"
+	Bzip2 = "bzip2"
+	// Zstd sources are detected but can not be decoded, reading them fails.
+	Zstd = "zstd"
+)
+
+var compressionExtensions = map[string]string{
+	".gz":   Gzip,
+	".gzip": Gzip,
+	".zip":  Zip,
+	".bz2":  Bzip2,
+	".zst":  Zstd,
+}
+
+var compressionMagics = []struct {
+	magic       []byte
+	next        string // Bytes allowed after the magic, any if empty.
+	compression string
+}{
+	{[]byte{0x1F, 0x8B}, "", Gzip},
+	{[]byte("PK\x03\x04"), "", Zip},
+	// The block size, from 100k to 900k, follows "BZh".
+	{[]byte("BZh"), "123456789", Bzip2},
+	{[]byte{0x28, 0xB5, 0x2F, 0xFD}, "", Zstd},
+}
+
+// ZipEntry selects the file read from zip archives. The pattern, whose syntax is the
+// one of path.Match, is matched against the entry name and its base name (e.g.
+// "data/*.csv" or "2017.csv"). The first matching entry is read. By default, archives
+// must have a single file, or a single CSV file.
+func ZipEntry(pattern string) SourceOpts {
+	return func(c *sourceConfig) error {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid zip entry pattern %q: %v", pattern, err)
+		}
+		c.zipEntry = pattern
+		return nil
+	}
+}
+
+// decompress detects the compression of the source, using the name extension and then
+// its first bytes, and returns the decompressed contents. Uncompressed sources are
+// returned as they are.
+func decompress(name string, src io.ReadCloser, c *sourceConfig) (io.ReadCloser, error) {
+	br := bufio.NewReader(src)
+	compression := compressionExtensions[strings.ToLower(path.Ext(sourcePath(name)))]
+	if compression == "" {
+		magic, _ := br.Peek(4)
+		for _, m := range compressionMagics {
+			if !bytes.HasPrefix(magic, m.magic) {
+				continue
+			}
+			if m.next == "" || len(magic) > len(m.magic) && strings.IndexByte(m.next, magic[len(m.magic)]) >= 0 {
+				compression = m.compression
+				break
+			}
+		}
+	}
+	var r io.Reader
+	var err error
+	switch compression {
+	case "":
+		r = br
+	case Gzip:
+		r, err = gzip.NewReader(br)
+	case Bzip2:
+		r = bzip2.NewReader(br)
+	case Zip:
+		r, err = openZipEntry(src, br, c.zipEntry)
+	case Zstd:
+		// Neither the standard library nor the vendored packages have a decoder.
+		err = fmt.Errorf("zstd compression is not supported")
+	}
+	if err != nil {
+		src.Close()
+		return nil, fmt.Errorf("error decompressing %s: %v", name, err)
+	}
+	return readCloser{Reader: r, Closer: src}, nil
+}
+
+// sourcePath returns the path of a file name or URL.
+func sourcePath(name string) string {
+	if strings.Contains(name, "://") {
+		if u, err := url.Parse(name); err == nil {
+			return u.Path
+		}
+	}
+	return name
+}
+
+// openZipEntry opens the zip entry matching the pattern. Files are read in place,
+// other sources are read into memory as zip archives can not be streamed.
+func openZipEntry(src io.ReadCloser, br io.Reader, pattern string) (io.Reader, error) {
+	var ra io.ReaderAt
+	var size int64
+	if f, ok := src.(interface {
+		io.ReaderAt
+		Stat() (os.FileInfo, error)
+	}); ok {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		ra, size = f, info.Size()
+	} else {
+		b, err := ioutil.ReadAll(br)
+		if err != nil {
+			return nil, err
+		}
+		ra, size = bytes.NewReader(b), int64(len(b))
+	}
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, err
+	}
+	var files, csvFiles []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if pattern != "" {
+			if m, _ := path.Match(pattern, f.Name); m {
+				return f.Open()
+			}
+			if m, _ := path.Match(pattern, path.Base(f.Name)); m {
+				return f.Open()
+			}
+			continue
+		}
+		files = append(files, f)
+		if strings.EqualFold(path.Ext(f.Name), ".csv") {
+			csvFiles = append(csvFiles, f)
+		}
+	}
+	switch {
+	case pattern != "":
+		return nil, fmt.Errorf("no zip entry matches %q", pattern)
+	case len(files) == 1:
+		return files[0].Open()
+	case len(csvFiles) == 1:
+		return csvFiles[0].Open()
+	}
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.Name
+	}
+	return nil, fmt.Errorf("zip archive has %d files (%s), use ZipEntry to choose one", len(files), strings.Join(names, ", "))
+}
+
+// readCloser reads from the decompressed reader and closes the source.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,171 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// "name\nfoo\n" compressed by bzip2.
+var bzip2Data = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x3e, 0xab, 0x79, 0x43, 0x00, 0x00,
+	0x03, 0x41, 0x00, 0x00, 0x10, 0x23, 0x03, 0xa0, 0x00, 0x22, 0x1a, 0x63, 0x50, 0x86, 0x03, 0x80,
+	0x95, 0x43, 0xc5, 0xdc, 0x91, 0x4e, 0x14, 0x24, 0x0f, 0xaa, 0xde, 0x50, 0xc0,
+}
+
+func gzipData(s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(s))
+	w.Close()
+	return buf.Bytes()
+}
+
+func zipData(files ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for i := 0; i < len(files); i += 2 {
+		f, _ := w.Create(files[i])
+		f.Write([]byte(files[i+1]))
+	}
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestFromFile_Compression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tableschema-go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	data := []struct {
+		desc string
+		name string
+		data []byte
+		opts []SourceOpts
+	}{
+		{"Gzip", "data.csv.gz", gzipData("name\nfoo\n"), nil},
+		{"GzipWithoutExtension", "data.csv", gzipData("name\nfoo\n"), nil},
+		{"Bzip2", "data.csv.bz2", bzip2Data, nil},
+		{"Bzip2WithoutExtension", "data", bzip2Data, nil},
+		{"ZipSingleFile", "data.zip", zipData("data.txt", "name\nfoo\n"), nil},
+		{"ZipSingleCSV", "data.zip", zipData("README", "foo", "data/data.csv", "name\nfoo\n"), nil},
+		{"ZipEntry", "data.zip", zipData("a.csv", "name\nbar\n", "data/b.csv", "name\nfoo\n"), []SourceOpts{ZipEntry("b.csv")}},
+		{"ZipEntryPattern", "data.zip", zipData("a.csv", "name\nbar\n", "data/b.csv", "name\nfoo\n"), []SourceOpts{ZipEntry("data/*")}},
+		{"Uncompressed", "data.csv", []byte("name\nfoo\n"), nil},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			path := filepath.Join(dir, d.name)
+			is.NoErr(ioutil.WriteFile(path, d.data, 0644))
+			table, err := NewTable(FromFile(path, d.opts...), LoadHeaders())
+			is.NoErr(err)
+			is.Equal(table.Headers(), []string{"name"})
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(rows, [][]string{{"foo"}})
+		})
+	}
+	errors := []struct {
+		desc string
+		name string
+		data []byte
+		opts []SourceOpts
+	}{
+		{"Zstd", "data.csv.zst", []byte{0x28, 0xB5, 0x2F, 0xFD}, nil},
+		{"ZstdWithoutExtension", "data.csv", []byte{0x28, 0xB5, 0x2F, 0xFD}, nil},
+		{"InvalidGzip", "data.csv.gz", []byte("name\nfoo\n"), nil},
+		{"ZipManyFiles", "data.zip", zipData("a.csv", "", "b.csv", ""), nil},
+		{"ZipEntryNotFound", "data.zip", zipData("a.csv", ""), []SourceOpts{ZipEntry("b.csv")}},
+		{"InvalidZipEntryPattern", "data.zip", zipData("a.csv", ""), []SourceOpts{ZipEntry("[")}},
+	}
+	for _, d := range errors {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			path := filepath.Join(dir, d.name)
+			is.NoErr(ioutil.WriteFile(path, d.data, 0644))
+			_, err := NewTable(FromFile(path, d.opts...), LoadHeaders())
+			is.True(err != nil)
+		})
+	}
+	t.Run("UncompressedBzip2Prefix", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(dir, "bzh.csv")
+		is.NoErr(ioutil.WriteFile(path, []byte("BZh,x\nfoo,bar\n"), 0644))
+		table, err := NewTable(FromFile(path), LoadHeaders())
+		is.NoErr(err)
+		is.Equal(table.Headers(), []string{"BZh", "x"})
+	})
+	t.Run("ZstdError", func(t *testing.T) {
+		is := is.New(t)
+		_, err := FromFile(filepath.Join(dir, "data.csv.zst"))()
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "zstd compression is not supported"))
+	})
+}
+
+func TestRemote_Compression(t *testing.T) {
+	is := is.New(t)
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data.zip":
+			w.Write(zipData("a.csv", "name\nbar\n", "b.csv", "name\nfoo\n"))
+		default:
+			w.Write(gzipData("name\nfoo\n"))
+		}
+	}
+	ts := httptest.NewServer(http.HandlerFunc(h))
+	defer ts.Close()
+	for _, src := range []Source{Remote(ts.URL + "/data?format=csv"), Remote(ts.URL+"/data.zip", ZipEntry("b.csv"))} {
+		table, err := NewTable(src, LoadHeaders())
+		is.NoErr(err)
+		rows, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo"}})
+	}
+}
+
+func TestWriterCompression(t *testing.T) {
+	t.Run("Gzip", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterCompression(Gzip), WriterEncoding(Latin1))
+		is.NoErr(w.Write([]string{"name"}))
+		is.NoErr(w.Write([]string{"café"}))
+		is.NoErr(w.Close())
+
+		r, err := gzip.NewReader(&buf)
+		is.NoErr(err)
+		got, err := ioutil.ReadAll(r)
+		is.NoErr(err)
+		is.Equal(string(got), "name\ncaf\xe9\n")
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterCompression(Zstd))
+		is.True(w.Write([]string{"foo"}) != nil)
+	})
+}
+
+func ExampleWriterCompression() {
+	var buf bytes.Buffer
+	w := NewDialectWriter(&buf, WriterCompression(Gzip))
+	w.Write([]string{"foo", "bar"})
+	w.Close()
+	r, _ := gzip.NewReader(&buf)
+	b, _ := ioutil.ReadAll(r)
+	fmt.Print(string(b))
+	// Output:foo,bar
+}
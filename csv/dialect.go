@@ -0,0 +1,153 @@
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// Dialect describes how a CSV file is formatted, as defined by the CSV Dialect
+// specification. The zero value of the boolean properties is false, which differs
+// from the specification defaults, so dialects should be created from DefaultDialect.
+// More at: https://specs.frictionlessdata.io/csv-dialect/
+type Dialect struct {
+	// Delimiter is the one-character string separating fields.
+	Delimiter string `json:"delimiter,omitempty"`
+	// LineTerminator ends records when writing: "\r\n", "\n" or "\r". When reading, records
+	// can always end with "\r\n" or "\n".
+	LineTerminator string `json:"lineTerminator,omitempty"`
+	// QuoteChar is the one-character string quoting fields which contain special characters.
+	QuoteChar string `json:"quoteChar,omitempty"`
+	// DoubleQuote indicates whether the quote character is escaped inside quoted fields by
+	// writing it twice.
+	DoubleQuote bool `json:"doubleQuote"`
+	// EscapeChar is the one-character string which escapes the following character (e.g. a
+	// backslash). It is ignored if empty.
+	EscapeChar string `json:"escapeChar,omitempty"`
+	// SkipInitialSpace indicates whether spaces following the delimiter are ignored.
+	SkipInitialSpace bool `json:"skipInitialSpace,omitempty"`
+	// Header indicates whether the first row contains the headers.
+	Header bool `json:"header"`
+	// CommentChar is the one-character string which starts comment lines, which are
+	// ignored. There are no comments if empty.
+	CommentChar string `json:"commentChar,omitempty"`
+	// CaseSensitiveHeader indicates whether the case of the headers is meaningful. It is
+	// informational only: headers are read as they are and the csv package does not match
+	// them against field names.
+	CaseSensitiveHeader bool `json:"caseSensitiveHeader,omitempty"`
+}
+
+// DefaultDialect holds the defaults defined by the CSV Dialect specification.
+var DefaultDialect = Dialect{
+	Delimiter:      ",",
+	LineTerminator: "\r\n",
+	QuoteChar:      `"`,
+	DoubleQuote:    true,
+	Header:         true,
+}
+
+// UnmarshalJSON sets *d to a copy of data, using the defaults of the specification for
+// missing properties.
+func (d *Dialect) UnmarshalJSON(data []byte) error {
+	// This is needed so it does not call UnmarshalJSON recursively.
+	type dialectAlias Dialect
+	u := dialectAlias(DefaultDialect)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	*d = Dialect(u)
+	return nil
+}
+
+// ReadDialect reads and parses a CSV Dialect descriptor from the specified reader.
+func ReadDialect(r io.Reader) (*Dialect, error) {
+	var d Dialect
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, err
+	}
+	if _, err := d.chars(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// LoadDialectFromFile loads and parses a CSV Dialect descriptor from a local file.
+func LoadDialectFromFile(path string) (*Dialect, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadDialect(f)
+}
+
+// WithDialect sets how the CSV file is formatted. If the dialect has a header, the first
+// row is used as table headers, as done by LoadHeaders.
+func WithDialect(d Dialect) CreationOpts {
+	return func(t *Table) error {
+		if _, err := d.chars(); err != nil {
+			return err
+		}
+		t.dialect = &d
+		if d.Header {
+			return LoadHeaders()(t)
+		}
+		return nil
+	}
+}
+
+// dialectChars holds the characters of a dialect. Optional characters are 0 if not set.
+type dialectChars struct {
+	delimiter, quote, escape, comment rune
+	// Whether a lone "\r" ends records.
+	crTerminator bool
+}
+
+// chars validates the dialect and returns its characters, applying the defaults to the
+// empty delimiter, quote character and line terminator.
+func (d Dialect) chars() (dialectChars, error) {
+	c := dialectChars{delimiter: ',', quote: '"'}
+	for _, s := range []struct {
+		name  string
+		value string
+		r     *rune
+	}{
+		{"delimiter", d.Delimiter, &c.delimiter},
+		{"quoteChar", d.QuoteChar, &c.quote},
+		{"escapeChar", d.EscapeChar, &c.escape},
+		{"commentChar", d.CommentChar, &c.comment},
+	} {
+		if s.value == "" {
+			continue
+		}
+		if utf8.RuneCountInString(s.value) != 1 {
+			return c, fmt.Errorf("invalid dialect: %s must be a one-character string:%q", s.name, s.value)
+		}
+		r, _ := utf8.DecodeRuneInString(s.value)
+		if r == '\r' || r == '\n' || r == utf8.RuneError {
+			return c, fmt.Errorf("invalid dialect: invalid %s:%q", s.name, s.value)
+		}
+		*s.r = r
+	}
+	switch d.LineTerminator {
+	case "", "\r\n", "\n":
+	case "\r":
+		c.crTerminator = true
+	default:
+		return c, fmt.Errorf("invalid dialect: invalid lineTerminator:%q", d.LineTerminator)
+	}
+	if c.delimiter == c.quote || c.delimiter == c.escape || c.delimiter == c.comment {
+		return c, fmt.Errorf("invalid dialect: delimiter %q must differ from quoteChar, escapeChar and commentChar", c.delimiter)
+	}
+	if c.escape == c.quote {
+		return c, fmt.Errorf("invalid dialect: escapeChar %q must differ from quoteChar, use doubleQuote instead", c.escape)
+	}
+	return c, nil
+}
+
+// standard reports whether encoding/csv supports the dialect quoting rules.
+func (c dialectChars) standard(d Dialect) bool {
+	return c.quote == '"' && d.DoubleQuote && c.escape == 0 && !c.crTerminator
+}
@@ -0,0 +1,249 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func ExampleWithDialect() {
+	d := DefaultDialect
+	d.Delimiter = ";"
+	table, _ := NewTable(FromString("name;age\nfoo;42\nbar;43"), WithDialect(d))
+	fmt.Println(table.Headers())
+	rows, _ := table.ReadAll()
+	fmt.Print(rows)
+	// Output:[name age]
+	// [[foo 42] [bar 43]]
+}
+
+func TestReadDialect(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		is := is.New(t)
+		d, err := ReadDialect(strings.NewReader(`{"delimiter":";"}`))
+		is.NoErr(err)
+		want := DefaultDialect
+		want.Delimiter = ";"
+		is.Equal(*d, want)
+	})
+	t.Run("AllProperties", func(t *testing.T) {
+		is := is.New(t)
+		d, err := ReadDialect(strings.NewReader(`{"delimiter":"\t","lineTerminator":"\n","quoteChar":"'","doubleQuote":false,"escapeChar":"\\","skipInitialSpace":true,"header":false,"commentChar":"#","caseSensitiveHeader":true}`))
+		is.NoErr(err)
+		is.Equal(*d, Dialect{
+			Delimiter:           "\t",
+			LineTerminator:      "\n",
+			QuoteChar:           "'",
+			DoubleQuote:         false,
+			EscapeChar:          `\`,
+			SkipInitialSpace:    true,
+			Header:              false,
+			CommentChar:         "#",
+			CaseSensitiveHeader: true,
+		})
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []string{
+			`{"delimiter":";;"}`,
+			`{"delimiter":"\n"}`,
+			`{"delimiter":"'","quoteChar":"'"}`,
+			`{"escapeChar":"\""}`,
+			`{"lineTerminator":"\t"}`,
+			`{"header":"yes"}`,
+		}
+		for _, d := range data {
+			is := is.New(t)
+			_, err := ReadDialect(strings.NewReader(d))
+			is.True(err != nil)
+		}
+	})
+}
+
+func TestWithDialect(t *testing.T) {
+	dialect := func(f func(d *Dialect)) Dialect {
+		d := DefaultDialect
+		f(&d)
+		return d
+	}
+	data := []struct {
+		desc    string
+		in      string
+		dialect Dialect
+		headers []string
+		rows    [][]string
+	}{
+		{
+			"Default",
+			"name,age\n\"foo, bar\",42\r\n\"baz \"\"qux\"\"\",43\n",
+			DefaultDialect,
+			[]string{"name", "age"},
+			[][]string{{"foo, bar", "42"}, {`baz "qux"`, "43"}},
+		},
+		{
+			"NoHeader",
+			"foo,42",
+			dialect(func(d *Dialect) { d.Header = false }),
+			nil,
+			[][]string{{"foo", "42"}},
+		},
+		{
+			"SkipInitialSpaceAndComments",
+			"# exported data\nname; age\nfoo; 42\n# end\n",
+			dialect(func(d *Dialect) { d.Delimiter, d.CommentChar, d.SkipInitialSpace = ";", "#", true }),
+			[]string{"name", "age"},
+			[][]string{{"foo", "42"}},
+		},
+		{
+			"QuoteChar",
+			"name|age\n'foo|bar'|42\n'it''s'|43\n",
+			dialect(func(d *Dialect) { d.Delimiter, d.QuoteChar = "|", "'" }),
+			[]string{"name", "age"},
+			[][]string{{"foo|bar", "42"}, {"it's", "43"}},
+		},
+		{
+			"EscapeChar",
+			"name,age\n\"foo \\\"bar\\\"\",42\nbaz\\,qux,43\n\"multi\nline\",44\n",
+			dialect(func(d *Dialect) { d.DoubleQuote, d.EscapeChar = false, `\` }),
+			[]string{"name", "age"},
+			[][]string{{`foo "bar"`, "42"}, {"baz,qux", "43"}, {"multi\nline", "44"}},
+		},
+		{
+			"CarriageReturnTerminator",
+			"name,age\rfoo,42\r\rbar,43",
+			dialect(func(d *Dialect) { d.LineTerminator, d.QuoteChar = "\r", "'" }),
+			[]string{"name", "age"},
+			[][]string{{"foo", "42"}, {"bar", "43"}},
+		},
+		{
+			"SkipInitialSpaceBeforeQuote",
+			"name, age\n'foo',  '42'\n",
+			dialect(func(d *Dialect) { d.QuoteChar, d.SkipInitialSpace = "'", true }),
+			[]string{"name", "age"},
+			[][]string{{"foo", "42"}},
+		},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromString(d.in), WithDialect(d.dialect))
+			is.NoErr(err)
+			is.Equal(len(table.Headers()), len(d.headers))
+			if len(d.headers) > 0 {
+				is.Equal(table.Headers(), d.headers)
+			}
+			iter, err := table.Iter()
+			is.NoErr(err)
+			defer iter.Close()
+			var rows [][]string
+			for iter.Next() {
+				rows = append(rows, iter.Row())
+			}
+			is.NoErr(iter.Err())
+			is.Equal(rows, d.rows)
+		})
+	}
+	t.Run("Errors", func(t *testing.T) {
+		data := []struct {
+			desc string
+			in   string
+		}{
+			{"WrongNumberOfFields", "a,b\nfoo\n"},
+			{"QuotedFieldNotClosed", "a,b\n'foo,42\n"},
+			{"EscapeAtEndOfFile", "a,b\nfoo,42\\"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				table, err := NewTable(FromString(d.in), WithDialect(dialect(func(d *Dialect) { d.QuoteChar, d.EscapeChar = "'", `\` })))
+				is.NoErr(err)
+				iter, err := table.Iter()
+				is.NoErr(err)
+				defer iter.Close()
+				for iter.Next() {
+				}
+				is.True(iter.Err() != nil)
+			})
+		}
+	})
+	t.Run("InvalidDialect", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromString("a"), WithDialect(dialect(func(d *Dialect) { d.Delimiter = "ab" })))
+		is.True(err != nil)
+	})
+}
+
+func TestNewRecordReader(t *testing.T) {
+	data := []struct {
+		desc      string
+		dialect   *Dialect
+		needLines bool
+		standard  bool
+	}{
+		{"Default", nil, false, true},
+		{"Delimiter", &Dialect{Delimiter: ";", DoubleQuote: true}, false, true},
+		{"EscapeChar", &Dialect{EscapeChar: "\\"}, false, false},
+		{"SkipInitialSpace", &Dialect{DoubleQuote: true, SkipInitialSpace: true}, false, false},
+		{"NeedLines", nil, true, false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r, err := newRecordReader(strings.NewReader(""), d.dialect, d.needLines)
+			is.NoErr(err)
+			_, ok := r.(*csvReader)
+			is.Equal(ok, d.standard) // whether encoding/csv is used
+		})
+	}
+}
+
+func TestWriterDialect(t *testing.T) {
+	dialect := func(f func(d *Dialect)) Dialect {
+		d := DefaultDialect
+		f(&d)
+		return d
+	}
+	data := []struct {
+		desc    string
+		dialect Dialect
+		want    string
+	}{
+		{"Default", DefaultDialect, "name,desc\r\nfoo,\"a, \"\"b\"\"\"\r\n"},
+		{"Semicolon", dialect(func(d *Dialect) { d.Delimiter, d.LineTerminator = ";", "\n" }), "name;desc\nfoo;\"a, \"\"b\"\"\"\n"},
+		{"QuoteChar", dialect(func(d *Dialect) { d.QuoteChar = "'" }), "name,desc\r\nfoo,'a, \"b\"'\r\n"},
+		{"EscapeChar", dialect(func(d *Dialect) { d.DoubleQuote, d.EscapeChar, d.LineTerminator = false, `\`, "\r" }), "name,desc\rfoo,\"a, \\\"b\\\"\"\r"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			var buf bytes.Buffer
+			w := NewDialectWriter(&buf, WriterDialect(d.dialect))
+			is.NoErr(w.WriteAll([][]string{{"name", "desc"}, {"foo", `a, "b"`}}))
+			is.Equal(buf.String(), d.want)
+
+			// Reading what has been written.
+			rd := d.dialect
+			rd.Header = false
+			table, err := NewTable(FromString(buf.String()), WithDialect(rd))
+			is.NoErr(err)
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(rows, [][]string{{"name", "desc"}, {"foo", `a, "b"`}})
+		})
+	}
+	t.Run("QuoteNeedsEscaping", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterDialect(dialect(func(d *Dialect) { d.DoubleQuote = false })))
+		is.True(w.Write([]string{`"foo"`}) != nil)
+	})
+	t.Run("InvalidDialect", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterDialect(dialect(func(d *Dialect) { d.Delimiter = ""; d.QuoteChar = ",," })))
+		is.True(w.Write([]string{"foo"}) != nil)
+		is.True(w.Error() != nil)
+	})
+}
@@ -0,0 +1,404 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encodings supported by tables and writers. Other names and aliases accepted by
+// Encoding and WriterEncoding are "utf8", "latin1", "latin-1", "iso8859-1" and "cp1252".
+const (
+	UTF8 = "utf-8"
+	// UTF16 uses the byte order mark to tell the byte order, defaulting to little-endian.
+	// Writers write little-endian with a byte order mark.
+	UTF16       = "utf-16"
+	UTF16LE     = "utf-16le"
+	UTF16BE     = "utf-16be"
+	Latin1      = "iso-8859-1"
+	Windows1252 = "windows-1252"
+)
+
+var encodingAliases = map[string]string{
+	"utf8":      UTF8,
+	"utf16":     UTF16,
+	"latin1":    Latin1,
+	"latin-1":   Latin1,
+	"iso8859-1": Latin1,
+	"cp1252":    Windows1252,
+}
+
+// Byte order marks.
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// Characters of the 0x80-0x9F range of Windows-1252. Zero means undefined.
+var windows1252Runes = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
+
+// EncodingError is returned when the table data is not valid in its encoding.
+type EncodingError struct {
+	Encoding string
+	// Row and Column (starting at 1, in characters) are where the invalid data is in
+	// the source. They are 0 if unknown.
+	Row    int
+	Column int
+	// Offset is the position of the invalid data, in bytes from the beginning of the source.
+	Offset int64
+}
+
+func (e *EncodingError) Error() string {
+	if e.Row == 0 {
+		return fmt.Sprintf("invalid %s data at byte %d", e.Encoding, e.Offset)
+	}
+	return fmt.Sprintf("line %d, column %d: invalid %s data at byte %d", e.Row, e.Column, e.Encoding, e.Offset)
+}
+
+func normalizeEncoding(name string) (string, error) {
+	enc := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := encodingAliases[enc]; ok {
+		enc = alias
+	}
+	switch enc {
+	case UTF8, UTF16, UTF16LE, UTF16BE, Latin1, Windows1252:
+		return enc, nil
+	}
+	return "", fmt.Errorf("unsupported encoding:%s", name)
+}
+
+// Encoding sets the character encoding of the CSV file, which is transcoded to UTF-8
+// before parsing. Byte order marks are removed. By default, the encoding is told by
+// the byte order mark and files without one are read as they are.
+func Encoding(name string) CreationOpts {
+	return func(t *Table) error {
+		enc, err := normalizeEncoding(name)
+		if err != nil {
+			return err
+		}
+		t.encoding = enc
+		return nil
+	}
+}
+
+// DetectEncoding samples the CSV file and sets its encoding to the detected one. See
+// Encoding.
+func DetectEncoding() CreationOpts {
+	return func(t *Table) error {
+		sample, err := readSample(t.source)
+		if err != nil {
+			return err
+		}
+		t.encoding = detectEncoding(sample)
+		return nil
+	}
+}
+
+// detectEncoding guesses the encoding of a sample: byte order marks first, then
+// UTF-16 (whose ASCII characters have a zero byte), UTF-8 and finally Windows-1252,
+// or Latin-1 if the sample has bytes undefined in Windows-1252.
+func detectEncoding(sample []byte) string {
+	switch {
+	case bytes.HasPrefix(sample, utf8BOM):
+		return UTF8
+	case bytes.HasPrefix(sample, utf16LEBOM), bytes.HasPrefix(sample, utf16BEBOM):
+		return UTF16
+	}
+	evenZeros, oddZeros := 0, 0
+	for i, b := range sample {
+		if b == 0 {
+			if i%2 == 0 {
+				evenZeros++
+			} else {
+				oddZeros++
+			}
+		}
+	}
+	pairs := len(sample) / 2
+	switch {
+	case pairs > 0 && oddZeros*4 > pairs && evenZeros < oddZeros:
+		return UTF16LE
+	case pairs > 0 && evenZeros*4 > pairs && oddZeros < evenZeros:
+		return UTF16BE
+	}
+	if _, _, invalid := decodeUTF8(sample, false); invalid < 0 {
+		return UTF8
+	}
+	for _, b := range sample {
+		if b >= 0x80 && b <= 0x9F && windows1252Runes[b-0x80] == 0 {
+			return Latin1
+		}
+	}
+	return Windows1252
+}
+
+// newDecodingReader creates a reader which transcodes r from the encoding to UTF-8.
+// An empty encoding means that the encoding is told by the byte order mark, if any.
+func newDecodingReader(r io.Reader, enc string) io.Reader {
+	return &decodingReader{r: r, enc: enc, buf: make([]byte, 4096), bom: true}
+}
+
+type decodingReader struct {
+	r   io.Reader
+	enc string
+	// Whether the byte order mark may still be found.
+	bom bool
+	buf []byte
+	// Undecoded bytes.
+	in []byte
+	// Decoded bytes not read yet.
+	out []byte
+	// Bytes of the source which have been decoded.
+	offset int64
+	// Number of lines and characters of the last line decoded, which locate encoding
+	// errors.
+	lines, col int
+	err        error
+}
+
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		n, err := d.r.Read(d.buf)
+		d.in = append(d.in, d.buf[:n]...)
+		if err != nil {
+			d.err = err
+		}
+		d.decode(d.err != nil)
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+// decode decodes the undecoded bytes. If atEOF is false, incomplete characters at the
+// end of the input are kept for the next call.
+func (d *decodingReader) decode(atEOF bool) {
+	if d.bom {
+		if !d.stripBOM(atEOF) {
+			return
+		}
+		d.bom = false
+	}
+	var out []byte
+	var consumed, invalid int
+	switch d.enc {
+	case "":
+		out, consumed, invalid = d.in, len(d.in), -1
+	case UTF8:
+		out, consumed, invalid = decodeUTF8(d.in, atEOF)
+	case UTF16LE:
+		out, consumed, invalid = decodeUTF16(d.in, false, atEOF)
+	case UTF16BE, UTF16:
+		out, consumed, invalid = decodeUTF16(d.in, d.enc == UTF16BE, atEOF)
+	case Latin1, Windows1252:
+		out, consumed, invalid = decodeSingleByte(d.in, d.enc == Windows1252)
+	}
+	d.out = append(d.out, out...)
+	if i := bytes.LastIndexByte(out, '\n'); i >= 0 {
+		d.lines += bytes.Count(out, []byte{'\n'})
+		d.col = 0
+		out = out[i+1:]
+	}
+	d.col += utf8.RuneCount(out)
+	if invalid >= 0 {
+		d.err = &EncodingError{Encoding: d.encodingName(), Row: d.lines + 1, Column: d.col + 1, Offset: d.offset + int64(invalid)}
+	}
+	d.offset += int64(consumed)
+	d.in = d.in[consumed:]
+}
+
+func (d *decodingReader) encodingName() string {
+	if d.enc == "" {
+		return UTF8
+	}
+	return d.enc
+}
+
+// stripBOM removes the byte order mark. It returns false if more bytes are needed to
+// tell whether there is one.
+func (d *decodingReader) stripBOM(atEOF bool) bool {
+	if len(d.in) < len(utf8BOM) && !atEOF && (bytes.HasPrefix(utf8BOM, d.in) || bytes.HasPrefix(utf16LEBOM, d.in) || bytes.HasPrefix(utf16BEBOM, d.in)) {
+		return false
+	}
+	bom := 0
+	switch {
+	case bytes.HasPrefix(d.in, utf8BOM) && (d.enc == "" || d.enc == UTF8):
+		d.enc, bom = UTF8, len(utf8BOM)
+	case bytes.HasPrefix(d.in, utf16LEBOM) && (d.enc == "" || d.enc == UTF16 || d.enc == UTF16LE):
+		d.enc, bom = UTF16LE, len(utf16LEBOM)
+	case bytes.HasPrefix(d.in, utf16BEBOM) && (d.enc == "" || d.enc == UTF16 || d.enc == UTF16BE):
+		d.enc, bom = UTF16BE, len(utf16BEBOM)
+	}
+	d.in = d.in[bom:]
+	d.offset += int64(bom)
+	return true
+}
+
+// decodeUTF8 validates UTF-8 input, returning the valid prefix, the number of consumed
+// bytes and the position of the first invalid byte (-1 if none).
+func decodeUTF8(in []byte, atEOF bool) ([]byte, int, int) {
+	for i := 0; i < len(in); {
+		if in[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(in[i:])
+		if r == utf8.RuneError && size <= 1 {
+			if !atEOF && !utf8.FullRune(in[i:]) {
+				return in[:i], i, -1
+			}
+			return in[:i], i, i
+		}
+		i += size
+	}
+	return in, len(in), -1
+}
+
+func decodeUTF16(in []byte, bigEndian, atEOF bool) ([]byte, int, int) {
+	var out []byte
+	var buf [utf8.UTFMax]byte
+	unit := func(i int) rune {
+		if bigEndian {
+			return rune(in[i])<<8 | rune(in[i+1])
+		}
+		return rune(in[i+1])<<8 | rune(in[i])
+	}
+	i := 0
+	for ; i+1 < len(in); i += 2 {
+		r := unit(i)
+		if utf16.IsSurrogate(r) {
+			if i+3 >= len(in) {
+				if atEOF {
+					return out, i, i
+				}
+				return out, i, -1
+			}
+			if r = utf16.DecodeRune(r, unit(i+2)); r == utf8.RuneError {
+				return out, i, i
+			}
+			i += 2
+		}
+		n := utf8.EncodeRune(buf[:], r)
+		out = append(out, buf[:n]...)
+	}
+	if atEOF && i < len(in) {
+		return out, i, i
+	}
+	return out, i, -1
+}
+
+func decodeSingleByte(in []byte, windows1252 bool) ([]byte, int, int) {
+	out := make([]byte, 0, len(in))
+	var buf [utf8.UTFMax]byte
+	for i, b := range in {
+		r := rune(b)
+		if windows1252 && b >= 0x80 && b <= 0x9F {
+			if r = windows1252Runes[b-0x80]; r == 0 {
+				return out, i, i
+			}
+		}
+		n := utf8.EncodeRune(buf[:], r)
+		out = append(out, buf[:n]...)
+	}
+	return out, len(in), -1
+}
+
+// encodingWriter transcodes UTF-8 to the encoding before writing to w.
+type encodingWriter struct {
+	w   io.Writer
+	enc string
+	// Whether the byte order mark is still to be written.
+	bom bool
+	// Incomplete UTF-8 characters at the end of the previous write.
+	pending []byte
+}
+
+func newEncodingWriter(w io.Writer, enc string) *encodingWriter {
+	return &encodingWriter{w: w, enc: enc, bom: enc == UTF16}
+}
+
+func (e *encodingWriter) Write(p []byte) (int, error) {
+	in := append(e.pending, p...)
+	var out []byte
+	if e.bom {
+		out = append(out, utf16LEBOM...)
+		e.bom = false
+	}
+	i := 0
+	for i < len(in) {
+		if !utf8.FullRune(in[i:]) {
+			break
+		}
+		r, size := utf8.DecodeRune(in[i:])
+		if r == utf8.RuneError && size == 1 {
+			return 0, fmt.Errorf("can not encode as %s: invalid UTF-8", e.enc)
+		}
+		var err error
+		if out, err = e.encodeRune(out, r); err != nil {
+			return 0, err
+		}
+		i += size
+	}
+	e.pending = append([]byte(nil), in[i:]...)
+	if _, err := e.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *encodingWriter) encodeRune(out []byte, r rune) ([]byte, error) {
+	switch e.enc {
+	case UTF16, UTF16LE, UTF16BE:
+		units := []uint16{uint16(r)}
+		if r >= 0x10000 {
+			r1, r2 := utf16.EncodeRune(r)
+			units = []uint16{uint16(r1), uint16(r2)}
+		}
+		for _, u := range units {
+			if e.enc == UTF16BE {
+				out = append(out, byte(u>>8), byte(u))
+			} else {
+				out = append(out, byte(u), byte(u>>8))
+			}
+		}
+		return out, nil
+	case Latin1, Windows1252:
+		if r < 0x80 || (r >= 0xA0 && r <= 0xFF) || (r <= 0xFF && e.enc == Latin1) {
+			return append(out, byte(r)), nil
+		}
+		if e.enc == Windows1252 {
+			for i, w := range windows1252Runes {
+				if w == r && w != 0 {
+					return append(out, byte(0x80+i)), nil
+				}
+			}
+		}
+		return out, fmt.Errorf("can not encode %q as %s", r, e.enc)
+	}
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(out, buf[:n]...), nil
+}
+
+// WriterEncoding sets the character encoding of the written file. An unsupported
+// encoding is reported by Write and WriteAll.
+func WriterEncoding(name string) WriterOpts {
+	return func(w *Writer) {
+		enc, err := normalizeEncoding(name)
+		if err != nil {
+			w.err = err
+			return
+		}
+		w.encoding = enc
+	}
+}
@@ -0,0 +1,175 @@
+package csv
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+
+	"github.com/matryer/is"
+)
+
+func utf16Bytes(s string, bigEndian bool) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return b
+}
+
+func TestEncoding(t *testing.T) {
+	data := []struct {
+		desc string
+		in   string
+		opts []CreationOpts
+		want [][]string
+	}{
+		{"UTF8BOM", "\xEF\xBB\xBFname\nfoo", nil, [][]string{{"name"}, {"foo"}}},
+		{"UTF16LEBOM", string(append([]byte{0xFF, 0xFE}, utf16Bytes("name\ncafé", false)...)), nil, [][]string{{"name"}, {"café"}}},
+		{"UTF16BEBOM", string(append([]byte{0xFE, 0xFF}, utf16Bytes("name\ncafé", true)...)), []CreationOpts{Encoding("UTF-16")}, [][]string{{"name"}, {"café"}}},
+		{"UTF16BE", string(utf16Bytes("name\n😀", true)), []CreationOpts{Encoding(UTF16BE)}, [][]string{{"name"}, {"😀"}}},
+		{"Latin1", "name\ncaf\xe9", []CreationOpts{Encoding("latin1")}, [][]string{{"name"}, {"café"}}},
+		{"Windows1252", "name\n\x80 caf\xe9", []CreationOpts{Encoding(Windows1252)}, [][]string{{"name"}, {"€ café"}}},
+		{"UTF8", "\xEF\xBB\xBFname\ncafé", []CreationOpts{Encoding(UTF8)}, [][]string{{"name"}, {"café"}}},
+		{"NoBOM", "name\ncaf\xe9", nil, [][]string{{"name"}, {"caf\xe9"}}},
+		{"DetectWindows1252", "name\n\x80 caf\xe9", []CreationOpts{DetectEncoding()}, [][]string{{"name"}, {"€ café"}}},
+		{"DetectLatin1", "name\n\x81caf\xe9", []CreationOpts{DetectEncoding()}, [][]string{{"name"}, {"\u0081café"}}},
+		{"DetectUTF8", "name\ncafé", []CreationOpts{DetectEncoding()}, [][]string{{"name"}, {"café"}}},
+		{"DetectUTF16LE", string(utf16Bytes("name\ncafé\n", false)), []CreationOpts{DetectEncoding()}, [][]string{{"name"}, {"café"}}},
+		{"DetectUTF16BE", string(utf16Bytes("name\ncafé\n", true)), []CreationOpts{DetectEncoding()}, [][]string{{"name"}, {"café"}}},
+		{"AutoDetect", string(append([]byte{0xFF, 0xFE}, utf16Bytes("name;age\r\ncafé;42\r\n", false)...)), []CreationOpts{AutoDetect()}, [][]string{{"café", "42"}}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromString(d.in), d.opts...)
+			is.NoErr(err)
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(rows, d.want)
+		})
+	}
+	t.Run("Unsupported", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromString(""), Encoding("ebcdic"))
+		is.True(err != nil)
+	})
+}
+
+func TestEncoding_Errors(t *testing.T) {
+	data := []struct {
+		desc     string
+		in       string
+		encoding string
+		row, col int
+		offset   int64
+	}{
+		{"UTF8", "\xEF\xBB\xBFname\nfoo\nb\xffr\n", UTF8, 3, 2, 13},
+		{"Windows1252", "name\nfoo\n\x81\n", Windows1252, 3, 1, 9},
+		{"UTF16OddLength", string(utf16Bytes("name\nfoo", false)) + "\x00", UTF16LE, 2, 4, 16},
+		{"UTF16UnpairedSurrogate", string(utf16Bytes("name\n", false)) + "\x00\xDCa\x00", UTF16LE, 2, 1, 10},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromString(d.in), Encoding(d.encoding))
+			is.NoErr(err)
+			iter, err := table.Iter()
+			is.NoErr(err)
+			defer iter.Close()
+			for iter.Next() {
+			}
+			encErr, ok := iter.Err().(*EncodingError)
+			is.True(ok)
+			is.Equal(encErr.Encoding, d.encoding)
+			is.Equal(encErr.Row, d.row)
+			is.Equal(encErr.Column, d.col)
+			is.Equal(encErr.Offset, d.offset)
+		})
+	}
+}
+
+func TestDecodingReader_SplitCharacters(t *testing.T) {
+	data := []struct {
+		desc     string
+		in       []byte
+		encoding string
+	}{
+		{"UTF8", []byte("café 😀"), UTF8},
+		{"UTF16", append([]byte{0xFF, 0xFE}, utf16Bytes("café 😀", false)...), UTF16},
+		{"BOMByBOM", append([]byte{0xEF, 0xBB, 0xBF}, "café"...), ""},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			r := newDecodingReader(iotest.OneByteReader(bytes.NewReader(d.in)), d.encoding)
+			got, err := ioutil.ReadAll(r)
+			is.NoErr(err)
+			is.True(strings.HasPrefix("café 😀", string(got)))
+		})
+	}
+}
+
+func TestWriterEncoding(t *testing.T) {
+	data := []struct {
+		desc     string
+		encoding string
+		want     []byte
+	}{
+		{"UTF8", UTF8, []byte("café,€\n")},
+		{"Latin1", Latin1, []byte("caf\xe9,\xa3\n")},
+		{"Windows1252", "cp1252", []byte("caf\xe9,\x80\n")},
+		{"UTF16", UTF16, append([]byte{0xFF, 0xFE}, utf16Bytes("café,😀\n", false)...)},
+		{"UTF16BE", UTF16BE, utf16Bytes("café,😀\n", true)},
+	}
+	records := map[string][]string{
+		UTF8:     {"café", "€"},
+		Latin1:   {"café", "£"},
+		"cp1252": {"café", "€"},
+		UTF16:    {"café", "😀"},
+		UTF16BE:  {"café", "😀"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			var buf bytes.Buffer
+			w := NewDialectWriter(&buf, WriterEncoding(d.encoding))
+			is.NoErr(w.WriteAll([][]string{records[d.encoding]}))
+			is.Equal(buf.Bytes(), d.want)
+
+			// Reading what has been written.
+			table, err := NewTable(FromString(buf.String()), Encoding(d.encoding))
+			is.NoErr(err)
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(rows, [][]string{records[d.encoding]})
+		})
+	}
+	t.Run("NotRepresentable", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterEncoding(Latin1))
+		is.True(w.WriteAll([][]string{{"€"}}) != nil)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		w := NewDialectWriter(&buf, WriterEncoding("ebcdic"), WriterDialect(DefaultDialect))
+		is.True(w.Write([]string{"foo"}) != nil)
+	})
+	t.Run("WithDialect", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		d := DefaultDialect
+		d.QuoteChar = "'"
+		w := NewDialectWriter(&buf, WriterEncoding(Windows1252), WriterDialect(d))
+		is.NoErr(w.WriteAll([][]string{{"€, café"}}))
+		is.Equal(buf.String(), "'\x80, caf\xe9'\r\n")
+	})
+}
@@ -0,0 +1,285 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// ErrEscape is reported by ParseError when the dialect escape character ends the source.
+var ErrEscape = errors.New("escape character at end of file")
+
+// ParseError reports a malformed record. Err is encoding/csv's ErrBareQuote, ErrQuote or
+// ErrFieldCount, or ErrEscape.
+type ParseError struct {
+	// StartLine is the line (starting at 1) where the record starts.
+	StartLine int
+	// Line and Column (starting at 1, in characters) are where the error occurred.
+	// Column is 0 for ErrFieldCount.
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Err == csv.ErrFieldCount {
+		return fmt.Sprintf("record on line %d: %v", e.Line, e.Err)
+	}
+	if e.StartLine != e.Line {
+		return fmt.Sprintf("record on line %d; parse error on line %d, column %d: %v", e.StartLine, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("parse error on line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// recordReader reads the records of a table.
+type recordReader interface {
+	Read() ([]string, error)
+	// startLine returns the line where the last record read starts, 0 if it is not known.
+	startLine() int
+}
+
+// newRecordReader creates a reader following the dialect, nil meaning the default dialect.
+// Records are read by encoding/csv, unless the dialect is not supported by it or the
+// physical lines of the records are needed. Records can have different numbers of
+// fields, unless encoding/csv is used: it then requires all records to have the same
+// number of fields as the first one.
+func newRecordReader(r io.Reader, d *Dialect, needLines bool) (recordReader, error) {
+	if d == nil {
+		d = &DefaultDialect
+	}
+	c, err := d.chars()
+	if err != nil {
+		return nil, err
+	}
+	if needLines || !c.standard(*d) || d.SkipInitialSpace {
+		return &dialectReader{r: bufio.NewReader(r), chars: c, dialect: *d, fieldsPerRecord: -1}, nil
+	}
+	cr := csv.NewReader(r)
+	cr.Comma, cr.Comment = c.delimiter, c.comment
+	return &csvReader{cr}, nil
+}
+
+// csvReader reads records of standard dialects using encoding/csv, which does not
+// tell the lines of the records.
+type csvReader struct {
+	r *csv.Reader
+}
+
+func (r *csvReader) Read() ([]string, error) {
+	record, err := r.r.Read()
+	if e, ok := err.(*csv.ParseError); ok {
+		parseErr := &ParseError{StartLine: parseErrorStartLine(e), Line: e.Line, Column: e.Column, Err: e.Err}
+		if e.Err == csv.ErrFieldCount {
+			parseErr.Line, parseErr.Column = parseErr.StartLine, 0
+		}
+		return record, parseErr
+	}
+	return record, err
+}
+
+func (r *csvReader) startLine() int {
+	return 0
+}
+
+// dialectReader reads records following a dialect. Unlike encoding/csv, it supports custom
+// quote and escape characters and keeps track of the physical lines of the records. As
+// encoding/csv, it skips empty lines and requires all records to have the same number of
+// fields as the first one, unless fieldsPerRecord is negative. After a *ParseError, the
+// reader continues with the following line.
+type dialectReader struct {
+	r       *bufio.Reader
+	chars   dialectChars
+	dialect Dialect
+	// Whether quotes can appear in unquoted fields and quoted fields can be followed by
+	// other characters, as encoding/csv's LazyQuotes.
+	lazyQuotes bool
+
+	// Line and column of the last character read, starting at 1, and line where the
+	// last record read starts.
+	line, col, recordLine int
+	// Whether the last character read ends its line.
+	eol             bool
+	fieldsPerRecord int
+	field           bytes.Buffer
+}
+
+func (r *dialectReader) Read() ([]string, error) {
+	for {
+		record, err := r.readRecord()
+		if encErr, ok := err.(*EncodingError); ok {
+			encErr.Row, encErr.Column = r.line, r.col+1
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record == nil {
+			continue
+		}
+		if r.fieldsPerRecord == 0 {
+			r.fieldsPerRecord = len(record)
+		} else if r.fieldsPerRecord > 0 && len(record) != r.fieldsPerRecord {
+			return record, &ParseError{StartLine: r.recordLine, Line: r.recordLine, Err: csv.ErrFieldCount}
+		}
+		return record, nil
+	}
+}
+
+func (r *dialectReader) startLine() int {
+	return r.recordLine
+}
+
+// readRune reads a rune, reporting whether it ends a line. "\r\n" is read as a single "\n".
+// As encoding/csv keeps invalid UTF-8 data, an invalid byte b is read as the negative
+// rune -1-b.
+func (r *dialectReader) readRune() (rune, bool, error) {
+	if r.line == 0 || r.eol {
+		r.line++
+		r.col, r.eol = 0, false
+	}
+	c, size, err := r.r.ReadRune()
+	if err != nil {
+		return 0, false, err
+	}
+	r.col++
+	if c == utf8.RuneError && size == 1 {
+		r.r.UnreadRune()
+		b, _ := r.r.ReadByte()
+		return -1 - rune(b), false, nil
+	}
+	switch c {
+	case '\n':
+		r.eol = true
+	case '\r':
+		next, _, err := r.r.ReadRune()
+		switch {
+		case err == io.EOF:
+			// As encoding/csv, a "\r" ending the input ends the line.
+			r.eol = true
+			return '\n', true, nil
+		case err != nil:
+			return 0, false, err
+		case next == '\n':
+			r.eol = true
+			return '\n', true, nil
+		}
+		r.r.UnreadRune()
+		r.eol = r.chars.crTerminator
+	}
+	return c, r.eol, nil
+}
+
+// parseError creates an error at the last character read, and skips the rest of its line
+// so the following record can be read.
+func (r *dialectReader) parseError(err error) *ParseError {
+	e := &ParseError{StartLine: r.recordLine, Line: r.line, Column: r.col, Err: err}
+	for !r.eol {
+		if _, _, err := r.readRune(); err != nil {
+			break
+		}
+	}
+	return e
+}
+
+// readRecord reads a line, returning a nil record for empty and comment lines.
+func (r *dialectReader) readRecord() ([]string, error) {
+	c, eol, err := r.readRune()
+	r.recordLine = r.line
+	if err != nil {
+		return nil, err
+	}
+	if eol {
+		return nil, nil
+	}
+	if r.chars.comment != 0 && c == r.chars.comment {
+		for !eol {
+			if _, eol, err = r.readRune(); err != nil {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+	var record []string
+	fieldStart, quoted := true, false
+	r.field.Reset()
+	for {
+		switch {
+		case fieldStart && r.dialect.SkipInitialSpace && (c == ' ' || c == '\t'):
+		case fieldStart && c == r.chars.quote:
+			quoted = true
+			fieldStart = false
+		case quoted:
+			switch {
+			case c == r.chars.escape && r.chars.escape != 0:
+				if c, _, err = r.readRune(); err != nil {
+					return nil, r.parseError(ErrEscape)
+				}
+				r.writeRune(c)
+			case c == r.chars.quote:
+				next, nextEOL, err := r.readRune()
+				switch {
+				case err == io.EOF:
+					return append(record, r.field.String()), nil
+				case err != nil:
+					return nil, err
+				case r.dialect.DoubleQuote && next == r.chars.quote:
+					r.writeRune(c)
+				case !r.lazyQuotes && !nextEOL && next != r.chars.delimiter:
+					// As encoding/csv, the error is at the quote.
+					e := r.parseError(csv.ErrQuote)
+					e.Column--
+					return nil, e
+				default:
+					// Closing quote, the rest of the field is not quoted.
+					quoted = false
+					c, eol = next, nextEOL
+					continue
+				}
+			default:
+				r.writeRune(c)
+			}
+		case eol:
+			return append(record, r.field.String()), nil
+		case c == r.chars.delimiter:
+			record = append(record, r.field.String())
+			r.field.Reset()
+			fieldStart = true
+		case c == r.chars.escape && r.chars.escape != 0:
+			if c, _, err = r.readRune(); err != nil {
+				return nil, r.parseError(ErrEscape)
+			}
+			r.writeRune(c)
+			fieldStart = false
+		case c == r.chars.quote && !r.lazyQuotes:
+			return nil, r.parseError(csv.ErrBareQuote)
+		default:
+			r.writeRune(c)
+			fieldStart = false
+		}
+		c, eol, err = r.readRune()
+		if err == io.EOF {
+			if quoted {
+				// The quote is missing at the end of the file.
+				e := r.parseError(csv.ErrQuote)
+				e.Column++
+				return nil, e
+			}
+			return append(record, r.field.String()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// writeRune appends the rune to the current field, invalid bytes as they were read.
+func (r *dialectReader) writeRune(c rune) {
+	if c < 0 {
+		r.field.WriteByte(byte(-1 - c))
+		return
+	}
+	r.field.WriteRune(c)
+}
@@ -0,0 +1,10 @@
+//go:build go1.10
+// +build go1.10
+
+package csv
+
+import "encoding/csv"
+
+func parseErrorStartLine(e *csv.ParseError) int {
+	return e.StartLine
+}
@@ -0,0 +1,11 @@
+//go:build !go1.10
+// +build !go1.10
+
+package csv
+
+import "encoding/csv"
+
+// encoding/csv only reports the line of the error before Go 1.10.
+func parseErrorStartLine(e *csv.ParseError) int {
+	return e.Line
+}
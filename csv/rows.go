@@ -0,0 +1,189 @@
+package csv
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rowOptions holds the row-level reading controls. Rows are numbered by the physical line
+// of the source where they start, starting at 1.
+type rowOptions struct {
+	// Lines of the header rows, in increasing order.
+	headerRows []int
+	// Separator of the cells of multi-line headers, a space if nil.
+	headerJoin *string
+	skipRows   int
+	// Rows matching any pattern or whose first cell starts with any prefix are skipped.
+	skipPatterns    []*regexp.Regexp
+	commentPrefixes []string
+	skipBlankRows   bool
+	// Number of data rows skipped and, if limited, returned.
+	offset  int
+	limit   int
+	limited bool
+}
+
+// needLines reports whether the row controls need the physical lines of the rows. Rows
+// skipped by their contents also need them, as the number of fields of the rows is only
+// checked once they are not skipped.
+func (o rowOptions) needLines() bool {
+	return len(o.headerRows) > 0 || o.skipRows > 0 || len(o.skipPatterns) > 0 || len(o.commentPrefixes) > 0 || o.skipBlankRows
+}
+
+// skip reports whether the row starting at the line is skipped by the row controls.
+// The delimiter joins the cells matched by the patterns.
+func (o rowOptions) skip(row []string, line int, delimiter rune) bool {
+	if o.skipRows > 0 && line <= o.skipRows {
+		return true
+	}
+	if o.skipBlankRows && blankRow(row) {
+		return true
+	}
+	for _, p := range o.commentPrefixes {
+		if len(row) > 0 && strings.HasPrefix(row[0], p) {
+			return true
+		}
+	}
+	if len(o.skipPatterns) > 0 {
+		text := strings.Join(row, string(delimiter))
+		for _, p := range o.skipPatterns {
+			if p.MatchString(text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// blankRow reports whether all cells of the row are empty or white space.
+func blankRow(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// joinHeaders joins the header rows column by column, ignoring empty cells.
+func joinHeaders(rows [][]string, sep string) []string {
+	if len(rows) == 1 {
+		return rows[0]
+	}
+	var headers []string
+	for _, r := range rows {
+		for i, c := range r {
+			if i == len(headers) {
+				headers = append(headers, "")
+			}
+			c = strings.TrimSpace(c)
+			switch {
+			case c == "":
+			case headers[i] == "":
+				headers[i] = c
+			default:
+				headers[i] += sep + c
+			}
+		}
+	}
+	return headers
+}
+
+// HeaderRows uses the rows starting at the given lines (starting at 1) as table headers,
+// for instance when titles or notes precede the header. The rows before the last header
+// row are skipped during iteration. Headers spanning several rows are joined column by
+// column, ignoring empty cells, with the HeaderJoin separator.
+func HeaderRows(lines ...int) CreationOpts {
+	return func(t *Table) error {
+		if len(lines) == 0 {
+			return fmt.Errorf("at least one header row is required")
+		}
+		rows := append([]int(nil), lines...)
+		sort.Ints(rows)
+		if rows[0] < 1 {
+			return fmt.Errorf("invalid header row:%d, rows start at 1", rows[0])
+		}
+		t.rows.headerRows = rows
+		return LoadHeaders()(t)
+	}
+}
+
+// HeaderJoin sets the separator joining the cells of headers spanning several rows
+// (see HeaderRows). The default is a space.
+func HeaderJoin(sep string) CreationOpts {
+	return func(t *Table) error {
+		t.rows.headerJoin = &sep
+		return nil
+	}
+}
+
+// SkipRows skips the first n lines of the source, for instance a title preceding the
+// header.
+func SkipRows(n int) CreationOpts {
+	return func(t *Table) error {
+		if n < 0 {
+			return fmt.Errorf("invalid number of rows to skip:%d", n)
+		}
+		t.rows.skipRows = n
+		return nil
+	}
+}
+
+// SkipRowsMatching skips the rows matching the regular expression. Rows are matched as
+// the text of their cells joined by the delimiter, e.g. "^Total,".
+func SkipRowsMatching(pattern string) CreationOpts {
+	return func(t *Table) error {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid row pattern %q: %v", pattern, err)
+		}
+		t.rows.skipPatterns = append(t.rows.skipPatterns, re)
+		return nil
+	}
+}
+
+// CommentPrefix skips the rows whose first cell starts with the prefix, e.g. "//" or "#".
+// Unlike the dialect CommentChar, the prefix can have several characters.
+func CommentPrefix(prefix string) CreationOpts {
+	return func(t *Table) error {
+		if prefix == "" {
+			return fmt.Errorf("comment prefix can not be empty")
+		}
+		t.rows.commentPrefixes = append(t.rows.commentPrefixes, prefix)
+		return nil
+	}
+}
+
+// SkipBlankRows skips the rows whose cells are all empty or white space, e.g. ",,".
+// Empty lines are always skipped.
+func SkipBlankRows() CreationOpts {
+	return func(t *Table) error {
+		t.rows.skipBlankRows = true
+		return nil
+	}
+}
+
+// Offset skips the first n data rows, which follow the header and are not skipped by
+// other row controls.
+func Offset(n int) CreationOpts {
+	return func(t *Table) error {
+		if n < 0 {
+			return fmt.Errorf("invalid offset:%d", n)
+		}
+		t.rows.offset = n
+		return nil
+	}
+}
+
+// Limit sets the maximum number of data rows read, after Offset.
+func Limit(n int) CreationOpts {
+	return func(t *Table) error {
+		if n < 0 {
+			return fmt.Errorf("invalid limit:%d", n)
+		}
+		t.rows.limit, t.rows.limited = n, true
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRowControls(t *testing.T) {
+	data := []struct {
+		desc    string
+		in      string
+		opts    []CreationOpts
+		headers []string
+		want    [][]string
+	}{
+		{"HeaderRow", "Report 2017\n\nname,age\nfoo,1\nbar,2", []CreationOpts{HeaderRows(3)}, []string{"name", "age"}, [][]string{{"foo", "1"}, {"bar", "2"}}},
+		{"MultiLineHeader", "Sales,,Costs\n2017,2018,2017\n1,2,3", []CreationOpts{HeaderRows(1, 2)}, []string{"Sales 2017", "2018", "Costs 2017"}, [][]string{{"1", "2", "3"}}},
+		{"HeaderJoin", "Title\nSales,Costs\n2017,2017\n1,2", []CreationOpts{HeaderRows(3, 2), HeaderJoin("_")}, []string{"Sales_2017", "Costs_2017"}, [][]string{{"1", "2"}}},
+		{"SkipRows", "Report\nNotes\nname\nfoo", []CreationOpts{SkipRows(2), LoadHeaders()}, []string{"name"}, [][]string{{"foo"}}},
+		{"SkipRowsAfterLoadHeaders", "Report\nname\nfoo", []CreationOpts{LoadHeaders(), SkipRows(1)}, []string{"name"}, [][]string{{"foo"}}},
+		{"SkipRowsWithoutHeaders", "Report\nfoo", []CreationOpts{SkipRows(1)}, nil, [][]string{{"foo"}}},
+		{"CommentPrefix", "// generated\nname\nfoo\n// bar\nbaz", []CreationOpts{CommentPrefix("//"), LoadHeaders()}, []string{"name"}, [][]string{{"foo"}, {"baz"}}},
+		{"SkipRowsMatching", "name,total\nfoo,1\nbar,2\nTotal,3", []CreationOpts{SkipRowsMatching("^Total,"), LoadHeaders()}, []string{"name", "total"}, [][]string{{"foo", "1"}, {"bar", "2"}}},
+		{"SkipBlankRows", ",\nname,age\n ,\nfoo,1\n,", []CreationOpts{SkipBlankRows(), LoadHeaders()}, []string{"name", "age"}, [][]string{{"foo", "1"}}},
+		{"Offset", "name\na\nb\nc", []CreationOpts{LoadHeaders(), Offset(1)}, []string{"name"}, [][]string{{"b"}, {"c"}}},
+		{"Limit", "name\na\nb\nc", []CreationOpts{LoadHeaders(), Limit(2)}, []string{"name"}, [][]string{{"a"}, {"b"}}},
+		{"OffsetAndLimit", "name\na\n\nb\nc", []CreationOpts{LoadHeaders(), Offset(1), Limit(1)}, []string{"name"}, [][]string{{"b"}}},
+		{"LimitZero", "name\na", []CreationOpts{LoadHeaders(), Limit(0)}, []string{"name"}, nil},
+		{"HeaderRowsWithoutDialectHeader", "Title\nname\nfoo", []CreationOpts{WithDialect(Dialect{Delimiter: ","}), HeaderRows(2)}, []string{"name"}, [][]string{{"foo"}}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromString(d.in), d.opts...)
+			is.NoErr(err)
+			is.Equal(len(table.Headers()), len(d.headers))
+			if d.headers != nil {
+				is.Equal(table.Headers(), d.headers)
+			}
+			rows, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(len(rows), len(d.want))
+			if d.want != nil {
+				is.Equal(rows, d.want)
+			}
+		})
+	}
+}
+
+func TestRowControls_PhysicalLines(t *testing.T) {
+	t.Run("EncodingError", func(t *testing.T) {
+		is := is.New(t)
+		in := "Title\n\nname,notes\nfoo,\"two\nlines\"\nb\xffr,x"
+		table, err := NewTable(FromString(in), Encoding(UTF8), HeaderRows(3))
+		is.NoErr(err)
+		iter, err := table.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		for iter.Next() {
+		}
+		encErr, ok := iter.Err().(*EncodingError)
+		is.True(ok)
+		is.Equal(encErr.Row, 6)
+	})
+	t.Run("WrongNumberOfFields", func(t *testing.T) {
+		is := is.New(t)
+		in := "Title\nname,age\n\nfoo,1\nbar"
+		table, err := NewTable(FromString(in), SkipRows(1), LoadHeaders())
+		is.NoErr(err)
+		iter, err := table.Iter()
+		is.NoErr(err)
+		defer iter.Close()
+		for iter.Next() {
+		}
+		is.Equal(iter.Err().Error(), "record on line 5: wrong number of fields")
+	})
+}
+
+func TestRowControls_Invalid(t *testing.T) {
+	data := []struct {
+		desc string
+		opt  CreationOpts
+	}{
+		{"NoHeaderRows", HeaderRows()},
+		{"HeaderRowZero", HeaderRows(0, 1)},
+		{"NegativeSkipRows", SkipRows(-1)},
+		{"InvalidPattern", SkipRowsMatching("(")},
+		{"EmptyCommentPrefix", CommentPrefix("")},
+		{"NegativeOffset", Offset(-1)},
+		{"NegativeLimit", Limit(-1)},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := NewTable(FromString("name\nfoo"), d.opt)
+			is.True(err != nil)
+		})
+	}
+}
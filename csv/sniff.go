@@ -0,0 +1,268 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Number of bytes sampled by Sniff.
+const sniffSampleSize = 64 * 1024
+
+var (
+	// Delimiters tried by Sniff, in order of preference.
+	sniffDelimiters = []rune{',', ';', '\t', '|', ':'}
+	// Quote characters tried by Sniff, in order of preference.
+	sniffQuoteChars = []rune{'"', '\''}
+)
+
+// Sniff samples the beginning of the source and detects its dialect: delimiter, quote
+// character, line terminator, whether spaces follow delimiters and whether the first row
+// is a header. It also returns how confident the detection is, from 0 (a guess) to 1.
+// The sample is decoded using the encoding detected as DetectEncoding does.
+func Sniff(source Source) (Dialect, float64, error) {
+	d, confidence, _, err := sniffSource(source, "")
+	return d, confidence, err
+}
+
+// AutoDetect sets the table dialect to the one detected by Sniff. If the first row is
+// detected as a header, it is used as table headers, as done by LoadHeaders. The
+// detected encoding is also set, unless the table already has one.
+func AutoDetect() CreationOpts {
+	return func(t *Table) error {
+		d, _, enc, err := sniffSource(t.source, t.encoding)
+		if err != nil {
+			return err
+		}
+		t.encoding = enc
+		return WithDialect(d)(t)
+	}
+}
+
+// sniffSource sniffs the dialect of the source, which is decoded using the passed-in
+// encoding, or the detected one if empty.
+func sniffSource(source Source, enc string) (Dialect, float64, string, error) {
+	sample, err := readSample(source)
+	if err != nil {
+		return Dialect{}, 0, "", err
+	}
+	if enc == "" {
+		enc = detectEncoding(sample)
+	}
+	// The sample may end with an incomplete character, invalid data is not sniffed.
+	decoded, err := ioutil.ReadAll(newDecodingReader(bytes.NewReader(sample), enc))
+	if _, ok := err.(*EncodingError); err != nil && !ok {
+		return Dialect{}, 0, "", err
+	}
+	d, confidence, err := sniff(string(decoded))
+	return d, confidence, enc, err
+}
+
+// readSample reads the beginning of the source, up to sniffSampleSize bytes.
+func readSample(source Source) ([]byte, error) {
+	src, err := peekSource(source)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	sample, err := ioutil.ReadAll(io.LimitReader(src, sniffSampleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(sample) > sniffSampleSize {
+		// Only complete lines are sampled.
+		sample = sample[:sniffSampleSize]
+		if i := bytes.LastIndexAny(sample, "\r\n"); i > 0 {
+			sample = sample[:i]
+		}
+	}
+	return sample, nil
+}
+
+func sniff(sample string) (Dialect, float64, error) {
+	if strings.TrimSpace(sample) == "" {
+		return Dialect{}, 0, fmt.Errorf("could not sniff dialect: empty sample")
+	}
+	d := DefaultDialect
+	d.LineTerminator = sniffLineTerminator(sample)
+	d.QuoteChar = string(sniffQuoteChar(sample))
+	delimiter, confidence, rows := sniffDelimiter(sample, d)
+	d.Delimiter = string(delimiter)
+	d.SkipInitialSpace = sniffInitialSpace(rows)
+	header, headerConfidence := sniffHeader(rows, d.SkipInitialSpace)
+	d.Header = header
+	return d, confidence * headerConfidence, nil
+}
+
+// sniffLineTerminator returns the most frequent line terminator.
+func sniffLineTerminator(sample string) string {
+	crlf := strings.Count(sample, "\r\n")
+	lf := strings.Count(sample, "\n") - crlf
+	cr := strings.Count(sample, "\r") - crlf
+	switch {
+	case crlf == 0 && lf == 0 && cr == 0:
+		return DefaultDialect.LineTerminator
+	case lf >= crlf && lf >= cr:
+		return "\n"
+	case cr > crlf:
+		return "\r"
+	}
+	return "\r\n"
+}
+
+var sniffQuoteRegexps = make(map[rune]*regexp.Regexp)
+
+func init() {
+	delims := regexp.QuoteMeta(string(sniffDelimiters))
+	for _, q := range sniffQuoteChars {
+		qs := regexp.QuoteMeta(string(q))
+		// Quoted fields, which start at the beginning of the line or after a delimiter
+		// and end at the end of the line or before a delimiter.
+		sniffQuoteRegexps[q] = regexp.MustCompile(`(?m)(?:^|[` + delims + `]) ?` + qs + `[^` + qs + `\r\n]*` + qs + ` ?(?:[` + delims + `]|\r?$)`)
+	}
+}
+
+// sniffQuoteChar returns the quote character which quotes more fields.
+func sniffQuoteChar(sample string) rune {
+	quote, max := sniffQuoteChars[0], 0
+	for _, q := range sniffQuoteChars {
+		if n := len(sniffQuoteRegexps[q].FindAllStringIndex(sample, -1)); n > max {
+			quote, max = q, n
+		}
+	}
+	return quote
+}
+
+// sniffDelimiter returns the delimiter which splits the sample rows into the most
+// consistent number of fields (more than one), its consistency and the parsed rows.
+// If no delimiter splits the rows, the default delimiter is returned with a 0.5
+// confidence.
+func sniffDelimiter(sample string, d Dialect) (rune, float64, [][]string) {
+	best, bestConsistency, bestFields := rune(0), 0.0, 0
+	var bestRows [][]string
+	for _, delimiter := range sniffDelimiters {
+		d.Delimiter = string(delimiter)
+		rows := sniffRows(sample, d)
+		if len(rows) == 0 {
+			continue
+		}
+		counts := make(map[int]int)
+		for _, r := range rows {
+			counts[len(r)]++
+		}
+		fields, n := 0, 0
+		for f, c := range counts {
+			if c > n || (c == n && f > fields) {
+				fields, n = f, c
+			}
+		}
+		if fields < 2 {
+			continue
+		}
+		consistency := float64(n) / float64(len(rows))
+		if consistency > bestConsistency || (consistency == bestConsistency && fields > bestFields) {
+			best, bestConsistency, bestFields, bestRows = delimiter, consistency, fields, rows
+		}
+	}
+	if best == 0 {
+		d.Delimiter = DefaultDialect.Delimiter
+		return ',', 0.5, sniffRows(sample, d)
+	}
+	return best, bestConsistency, bestRows
+}
+
+// sniffRows parses the sample rows, stopping at the first error (e.g. a quoted field
+// which is not closed).
+func sniffRows(sample string, d Dialect) [][]string {
+	c, err := d.chars()
+	if err != nil {
+		return nil
+	}
+	r := &dialectReader{r: bufio.NewReader(strings.NewReader(sample)), chars: c, dialect: d, lazyQuotes: true, fieldsPerRecord: -1}
+	var rows [][]string
+	for {
+		row, err := r.Read()
+		if err != nil {
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+// sniffInitialSpace reports whether most fields following a delimiter start with a space.
+func sniffInitialSpace(rows [][]string) bool {
+	spaced, total := 0, 0
+	for _, r := range rows {
+		for _, f := range r[1:] {
+			if f == "" {
+				continue
+			}
+			total++
+			if f[0] == ' ' {
+				spaced++
+			}
+		}
+	}
+	return total > 0 && spaced*2 > total
+}
+
+// sniffHeader reports whether the first row is a header, as Python's csv.Sniffer does:
+// columns whose values share a kind (numbers or strings of the same length) vote for
+// a header if the first row value is of another kind. If no column votes, the first
+// row is considered a header with a 0.5 confidence.
+func sniffHeader(rows [][]string, skipInitialSpace bool) (bool, float64) {
+	if len(rows) < 2 {
+		return true, 0.5
+	}
+	kind := func(v string) string {
+		if skipInitialSpace {
+			v = strings.TrimLeft(v, " ")
+		}
+		if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return "number"
+		}
+		return "length:" + strconv.Itoa(len(v))
+	}
+	header := rows[0]
+	votes, voters := 0, 0
+	for col := range header {
+		colKind := ""
+		consistent := true
+		for _, r := range rows[1:] {
+			if len(r) != len(header) || r[col] == "" {
+				continue
+			}
+			k := kind(r[col])
+			if colKind == "" {
+				colKind = k
+			} else if k != colKind {
+				consistent = false
+				break
+			}
+		}
+		if !consistent || colKind == "" {
+			continue
+		}
+		voters++
+		if kind(header[col]) != colKind {
+			votes++
+		} else {
+			votes--
+		}
+	}
+	if voters == 0 {
+		return true, 0.5
+	}
+	if votes < 0 {
+		return false, float64(-votes) / float64(voters)
+	}
+	if votes == 0 {
+		return true, 0.5
+	}
+	return true, float64(votes) / float64(voters)
+}
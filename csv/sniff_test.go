@@ -0,0 +1,89 @@
+package csv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func ExampleAutoDetect() {
+	table, _ := NewTable(FromString("name;age\nfoo;42\nbar;43\n"), AutoDetect())
+	fmt.Println(table.Headers())
+	rows, _ := table.ReadAll()
+	fmt.Print(rows)
+	// Output:[name age]
+	// [[foo 42] [bar 43]]
+}
+
+func TestSniff(t *testing.T) {
+	data := []struct {
+		desc             string
+		in               string
+		delimiter        string
+		quoteChar        string
+		lineTerminator   string
+		skipInitialSpace bool
+		header           bool
+		confidence       float64
+	}{
+		{"Comma", "name,age\nfoo,42\nbar,43\n", ",", `"`, "\n", false, true, 1},
+		{"Semicolon", "name;age;city\r\nfoo;42;\"Paris; France\"\r\nbar;43;Rome\r\n", ";", `"`, "\r\n", false, true, 1},
+		{"Tab", "id\tscore\n1\t0.5\n2\t0.7\n", "\t", `"`, "\n", false, true, 1},
+		{"Pipe", "a|b\n1|2\n3|4\n", "|", `"`, "\n", false, true, 1},
+		{"SingleQuotes", "'name','age'\r'foo, bar','42'\r'baz','43'\r", ",", "'", "\r", false, true, 1},
+		{"InitialSpace", "name, age\nfoo, 42\nbar, 43\n", ",", `"`, "\n", true, true, 1},
+		{"NoHeader", "foo,42\nbar,43\nbaz,44\n", ",", `"`, "\n", false, false, 1},
+		{"SingleColumn", "foo\nbarbaz\n", ",", `"`, "\n", false, true, 0.5},
+		{"InconsistentRows", "a,b\n1,2\n3,4,5\n6,7\n", ",", `"`, "\n", false, true, 0.75},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			dialect, confidence, err := Sniff(FromString(d.in))
+			is.NoErr(err)
+			is.Equal(dialect.Delimiter, d.delimiter)
+			is.Equal(dialect.QuoteChar, d.quoteChar)
+			is.Equal(dialect.LineTerminator, d.lineTerminator)
+			is.Equal(dialect.SkipInitialSpace, d.skipInitialSpace)
+			is.Equal(dialect.Header, d.header)
+			is.Equal(confidence, d.confidence)
+		})
+	}
+	t.Run("Empty", func(t *testing.T) {
+		is := is.New(t)
+		_, _, err := Sniff(FromString(" \n"))
+		is.True(err != nil)
+	})
+	t.Run("SourceError", func(t *testing.T) {
+		is := is.New(t)
+		_, _, err := Sniff(errorSource())
+		is.True(err != nil)
+	})
+}
+
+func TestAutoDetect(t *testing.T) {
+	t.Run("NoHeader", func(t *testing.T) {
+		is := is.New(t)
+		table, err := NewTable(FromString("foo;42\nbar;43\n"), AutoDetect(), LoadHeaders())
+		is.NoErr(err)
+		is.Equal(len(table.Headers()), 0)
+		rows, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo", "42"}, {"bar", "43"}})
+	})
+	t.Run("LoadHeadersAfterDetection", func(t *testing.T) {
+		is := is.New(t)
+		table, err := NewTable(FromString("name;age\nfoo;42\nbar;43\n"), AutoDetect(), LoadHeaders())
+		is.NoErr(err)
+		is.Equal(table.Headers(), []string{"name", "age"})
+		rows, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo", "42"}, {"bar", "43"}})
+	})
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromString(""), AutoDetect())
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,169 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/frictionlessdata/tableschema-go/remote"
+)
+
+// SourceOpts defines functional options for creating Sources.
+type SourceOpts func(c *sourceConfig) error
+
+type sourceConfig struct {
+	// Pattern of the zip entry to read.
+	zipEntry string
+	// Options of the requests made by Remote.
+	remote remote.Options
+}
+
+func newSourceConfig(opts []SourceOpts) (*sourceConfig, error) {
+	c := &sourceConfig{}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// RemoteOptions configures the requests made by Remote, e.g. their context, headers and
+// retries.
+func RemoteOptions(o remote.Options) SourceOpts {
+	return func(c *sourceConfig) error {
+		c.remote = o
+		return nil
+	}
+}
+
+// Maximum number of bytes kept by single-pass sources to be read again, e.g. the
+// beginning of the source read by LoadHeaders or AutoDetect.
+const replayLimit = 1 << 20
+
+// FromReader defines a single-pass Source reading from r, for instance a pipe or a
+// network stream. The source can be iterated only once: the beginning read while
+// creating the table (e.g. by LoadHeaders or AutoDetect) is kept in memory, up to
+// 1MB, and read again by the iteration; a following iteration returns an error.
+// Compressed contents (see Gzip, Zip and Bzip2) are decompressed while reading. The
+// reader is not closed.
+func FromReader(r io.Reader, opts ...SourceOpts) Source {
+	s := &readerSource{r: r}
+	return func() (io.ReadCloser, error) {
+		c, err := newSourceConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+		src, err := s.open()
+		if err != nil {
+			return nil, err
+		}
+		return decompress("", src, c)
+	}
+}
+
+// FromStdin defines a single-pass Source reading from the standard input, see FromReader.
+func FromStdin(opts ...SourceOpts) Source {
+	return FromReader(os.Stdin, opts...)
+}
+
+// readerSource keeps the data read from a single-pass reader, so it can be read again
+// until a reader which is not peeking (see peekSource) is closed or reads beyond the
+// replayLimit.
+type readerSource struct {
+	mu       sync.Mutex
+	r        io.Reader
+	buf      []byte
+	err      error
+	reading  bool
+	consumed bool
+}
+
+func (s *readerSource) open() (*replayReader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.consumed:
+		return nil, fmt.Errorf("reader source can only be read once")
+	case s.reading:
+		return nil, fmt.Errorf("reader source is already being read")
+	}
+	s.reading = true
+	return &replayReader{s: s}, nil
+}
+
+// replayReader reads the data kept by the source, and then from its reader.
+type replayReader struct {
+	s       *readerSource
+	pos     int
+	peeking bool
+}
+
+func (r *replayReader) Read(p []byte) (int, error) {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.pos < len(s.buf) {
+		n := copy(p, s.buf[r.pos:])
+		r.pos += n
+		return n, nil
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.r.Read(p)
+	s.err = err
+	switch {
+	case s.consumed:
+	case len(s.buf)+n <= replayLimit:
+		s.buf = append(s.buf, p[:n]...)
+		r.pos += n
+	case r.peeking:
+		s.consumed, s.buf = true, nil
+		return 0, fmt.Errorf("reader source can not be read again after %d bytes", replayLimit)
+	default:
+		// The data can not be read again.
+		s.consumed, s.buf = true, nil
+	}
+	return n, err
+}
+
+func (r *replayReader) Close() error {
+	s := r.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reading = false
+	if !r.peeking {
+		s.consumed, s.buf = true, nil
+	}
+	return nil
+}
+
+func (r *replayReader) peek() {
+	r.peeking = true
+}
+
+// peeker is implemented by sources which need to know the reader is peeking.
+type peeker interface {
+	peek()
+}
+
+func (r readCloser) peek() {
+	if p, ok := r.Closer.(peeker); ok {
+		p.peek()
+	}
+}
+
+// peekSource opens the source to read its beginning, which single-pass sources (see
+// FromReader) keep so it can be read again.
+func peekSource(source Source) (io.ReadCloser, error) {
+	src, err := source()
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := src.(peeker); ok {
+		p.peek()
+	}
+	return src, nil
+}
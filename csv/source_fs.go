@@ -0,0 +1,26 @@
+//go:build go1.16
+// +build go1.16
+
+package csv
+
+import (
+	"io"
+	"io/fs"
+)
+
+// FromFS defines a Source reading the named file from the file system, for instance
+// test fixtures embedded with embed.FS. Compressed files (see Gzip, Zip and Bzip2) are
+// decompressed while reading.
+func FromFS(fsys fs.FS, name string, opts ...SourceOpts) Source {
+	return func() (io.ReadCloser, error) {
+		c, err := newSourceConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(name, f, c)
+	}
+}
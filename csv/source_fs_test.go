@@ -0,0 +1,34 @@
+//go:build go1.16
+// +build go1.16
+
+package csv
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/matryer/is"
+)
+
+func TestFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"testdata/data.csv":    {Data: []byte("name\nfoo\n")},
+		"testdata/data.csv.gz": {Data: gzipData("name\nfoo\n")},
+		"testdata/data.zip":    {Data: zipData("data.csv", "name\nfoo\n", "README", "notes")},
+	}
+	for _, name := range []string{"testdata/data.csv", "testdata/data.csv.gz", "testdata/data.zip"} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromFS(fsys, name), LoadHeaders())
+			is.NoErr(err)
+			got, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(got, [][]string{{"foo"}})
+		})
+	}
+	t.Run("NotFound", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromFS(fsys, "testdata/missing.csv"), LoadHeaders())
+		is.True(err != nil)
+	})
+}
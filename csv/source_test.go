@@ -0,0 +1,84 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestFromReader(t *testing.T) {
+	data := []struct {
+		desc string
+		in   []byte
+		opts []CreationOpts
+		want [][]string
+	}{
+		{"NoOpts", []byte("name\nfoo"), nil, [][]string{{"name"}, {"foo"}}},
+		{"LoadHeaders", []byte("name\nfoo"), []CreationOpts{LoadHeaders()}, [][]string{{"foo"}}},
+		{"AutoDetect", []byte("name;age\nfoo;1\nbar;2\n"), []CreationOpts{AutoDetect()}, [][]string{{"foo", "1"}, {"bar", "2"}}},
+		{"Gzip", gzipData("name\nfoo\n"), []CreationOpts{LoadHeaders()}, [][]string{{"foo"}}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			table, err := NewTable(FromReader(bytes.NewReader(d.in)), d.opts...)
+			is.NoErr(err)
+			got, err := table.ReadAll()
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("IterTwice", func(t *testing.T) {
+		is := is.New(t)
+		table, err := NewTable(FromReader(strings.NewReader("name\nfoo")), LoadHeaders())
+		is.NoErr(err)
+		iter, err := table.Iter()
+		is.NoErr(err)
+		for iter.Next() {
+		}
+		is.NoErr(iter.Close())
+		_, err = table.Iter()
+		is.True(err != nil) // reader sources can only be read once
+	})
+	t.Run("BeyondReplayLimit", func(t *testing.T) {
+		is := is.New(t)
+		var buf bytes.Buffer
+		buf.WriteString("name\n")
+		rows := replayLimit / 4
+		for i := 0; i < rows; i++ {
+			fmt.Fprintf(&buf, "%d\n", i%10)
+		}
+		table, err := NewTable(FromReader(&buf), LoadHeaders())
+		is.NoErr(err)
+		is.Equal(table.Headers(), []string{"name"})
+		got, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(len(got), rows)
+		_, err = table.Iter()
+		is.True(err != nil) // reader sources can only be read once
+	})
+	t.Run("PeekBeyondReplayLimit", func(t *testing.T) {
+		is := is.New(t)
+		in := strings.Repeat("\n", replayLimit) + "name\nfoo"
+		_, err := NewTable(FromReader(strings.NewReader(in)), LoadHeaders())
+		is.True(err != nil) // headers are beyond the data kept to be read again
+	})
+}
+
+func TestRemote_Streaming(t *testing.T) {
+	is := is.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(gzipData("name\nfoo\n"))
+	}))
+	defer ts.Close()
+	table, err := NewTable(Remote(ts.URL+"/data.csv.gz"), LoadHeaders())
+	is.NoErr(err)
+	got, err := table.ReadAll()
+	is.NoErr(err)
+	is.Equal(got, [][]string{{"foo"}})
+}
@@ -1,28 +1,40 @@
 package csv
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"strings"
-	"sync"
-	"time"
 
+	"github.com/frictionlessdata/tableschema-go/remote"
 	"github.com/frictionlessdata/tableschema-go/table"
 )
 
 // Table represents a Table backed by a CSV physical representation.
 type Table struct {
-	headers     []string
-	source      Source
+	headers []string
+	source  Source
+	dialect *Dialect
+	// Encoding of the source, empty if told by the byte order mark.
+	encoding string
+	rows     rowOptions
+	// Whether headers are loaded when the table is created.
+	loadHeaders bool
+	// Whether the first row is skipped, as it holds the headers.
 	skipHeaders bool
+	// Last line of the header rows, which are skipped with the rows preceding them.
+	headerLine int
+	// Called with the malformed records skipped, nil if they are errors.
+	lenient func(*ParseError)
 }
 
 // NewTable creates a table.Table from the CSV table physical representation.
-// CreationOpts are executed in the order they are declared.
+// CreationOpts are executed in the order they are declared. Headers are loaded
+// (see LoadHeaders) once all options are executed, so row controls apply to
+// them regardless of the order.
 func NewTable(source Source, opts ...CreationOpts) (*Table, error) {
 	t := Table{source: source}
 	for _, opt := range opts {
@@ -30,22 +42,119 @@ func NewTable(source Source, opts ...CreationOpts) (*Table, error) {
 			return nil, err
 		}
 	}
+	if t.loadHeaders && (len(t.rows.headerRows) > 0 || t.dialect == nil || t.dialect.Header) {
+		if err := t.readHeaders(); err != nil {
+			return nil, err
+		}
+	}
 	return &t, nil
 }
 
 // Iter returns an Iterator to read the table. Iter returns an error
 // if the table physical source can not be iterated.
 // The iteration process always start at the beginning of the CSV and
-// is backed by a new reading.
+// is backed by a new reading. Rows skipped by the row controls (e.g. SkipRows
+// or CommentPrefix) are not returned.
 func (table *Table) Iter() (table.Iterator, error) {
-	src, err := table.source()
+	return table.iter(table.rows, false)
+}
+
+// IterContext is like Iter, but the iteration stops when the context is done, Err
+// returning the context error. The context is checked before reading each row,
+// including the rows skipped by the row controls.
+func (table *Table) IterContext(ctx context.Context) (table.Iterator, error) {
+	iter, err := table.iter(table.rows, false)
 	if err != nil {
 		return nil, err
 	}
-	return newIterator(src, table.skipHeaders), nil
+	iter.ctx = ctx
+	return iter, nil
+}
+
+// iter creates an iterator following the row controls. Peeking iterators only read the
+// beginning of the source, see peekSource.
+func (table *Table) iter(rows rowOptions, peek bool) (*csvIterator, error) {
+	open := table.source
+	if peek {
+		open = func() (io.ReadCloser, error) { return peekSource(table.source) }
+	}
+	src, err := open()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := newRecordReader(newDecodingReader(src, table.encoding), table.dialect, rows.needLines())
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	delimiter := ','
+	if table.dialect != nil {
+		c, _ := table.dialect.chars()
+		delimiter = c.delimiter
+	}
+	return &csvIterator{
+		source:      src,
+		reader:      reader,
+		delimiter:   delimiter,
+		rows:        rows,
+		skipHeaders: table.skipHeaders,
+		headerLine:  table.headerLine,
+		lenient:     table.lenient,
+	}, nil
+}
+
+// readHeaders reads the headers from the header rows or, if they are not set, the
+// first row which is not skipped. Headers already set after LoadHeaders are kept.
+func (table *Table) readHeaders() error {
+	// Offset and limit only apply to data rows.
+	rows := table.rows
+	rows.offset, rows.limited = 0, false
+	table.skipHeaders, table.headerLine = false, 0
+	iter, err := table.iter(rows, true)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	// Malformed header rows are errors.
+	iter.lenient = nil
+	if len(rows.headerRows) == 0 {
+		if iter.Next() && table.headers == nil {
+			table.headers = iter.Row()
+		}
+		table.skipHeaders = true
+		return iter.Err()
+	}
+	last := rows.headerRows[len(rows.headerRows)-1]
+	var headerRows [][]string
+	for i := 0; i < len(rows.headerRows); {
+		record, err := iter.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line := iter.reader.startLine()
+		for i < len(rows.headerRows) && rows.headerRows[i] < line {
+			i++
+		}
+		if i < len(rows.headerRows) && rows.headerRows[i] == line {
+			headerRows = append(headerRows, record)
+		}
+	}
+	sep := " "
+	if rows.headerJoin != nil {
+		sep = *rows.headerJoin
+	}
+	if len(headerRows) > 0 && table.headers == nil {
+		table.headers = joinHeaders(headerRows, sep)
+	}
+	table.headerLine = last
+	return nil
 }
 
-// ReadAll reads all rows from the table and return it as strings.
+// ReadAll reads all rows from the table and return it as strings. It returns the
+// first error found while reading, e.g. a *ParseError or *EncodingError.
 func (table *Table) ReadAll() ([][]string, error) {
 	var r [][]string
 	iter, err := table.Iter()
@@ -56,6 +165,9 @@ func (table *Table) ReadAll() ([][]string, error) {
 	for iter.Next() {
 		r = append(r, iter.Row())
 	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
 	return r, nil
 }
 
@@ -65,38 +177,83 @@ func (table *Table) Headers() []string {
 }
 
 func newIterator(source io.ReadCloser, skipHeaders bool) *csvIterator {
+	reader, _ := newRecordReader(source, nil, false)
 	return &csvIterator{
 		source:      source,
-		reader:      csv.NewReader(source),
+		reader:      reader,
+		delimiter:   ',',
 		skipHeaders: skipHeaders,
 	}
 }
 
 type csvIterator struct {
-	reader *csv.Reader
-	source io.ReadCloser
+	reader    recordReader
+	source    io.ReadCloser
+	rows      rowOptions
+	delimiter rune
 
 	current     []string
 	err         error
 	skipHeaders bool
+	headerLine  int
+	// Number of fields of the first row which is not skipped.
+	fields int
+	// Number of data rows skipped by the offset and returned.
+	offset, returned int
+	// Called with the malformed records skipped, nil if they are errors.
+	lenient func(*ParseError)
+	// Stops the iteration when done, if not nil.
+	ctx context.Context
 }
 
 func (i *csvIterator) Next() bool {
-	if i.err != nil {
-		return false
-	}
-	var err error
-	i.current, err = i.reader.Read()
-	if err != io.EOF {
-		i.err = err
-	}
-	if i.skipHeaders {
-		i.skipHeaders = false
-		i.Next()
+	i.current = nil
+	for i.err == nil && (!i.rows.limited || i.returned < i.rows.limit) {
+		if i.ctx != nil && i.ctx.Err() != nil {
+			i.err = i.ctx.Err()
+			return false
+		}
+		record, err := i.reader.Read()
+		line := i.reader.startLine()
+		if parseErr, ok := err.(*ParseError); ok && i.lenient != nil && parseErr.StartLine > i.headerLine {
+			i.lenient(parseErr)
+			continue
+		}
+		if err != nil {
+			if err != io.EOF {
+				i.err = err
+			}
+			return false
+		}
+		if (i.headerLine > 0 && line <= i.headerLine) || i.rows.skip(record, line, i.delimiter) {
+			continue
+		}
+		if i.fields == 0 {
+			i.fields = len(record)
+		} else if len(record) != i.fields {
+			err = &ParseError{StartLine: line, Line: line, Err: csv.ErrFieldCount}
+		}
+		if err != nil {
+			if i.lenient == nil {
+				i.err = err
+				return false
+			}
+			i.lenient(err.(*ParseError))
+			continue
+		}
+		switch {
+		case i.skipHeaders:
+			i.skipHeaders = false
+		case i.offset < i.rows.offset:
+			i.offset++
+		default:
+			i.current = record
+			i.returned++
+			return true
+		}
 	}
-	return err == nil
+	return false
 }
-
 func (i *csvIterator) Row() []string {
 	return i.current
 }
@@ -115,39 +272,37 @@ type CreationOpts func(t *Table) error
 // Source defines a table physical data source.
 type Source func() (io.ReadCloser, error)
 
-// FromFile defines a file-based Source.
-func FromFile(path string) Source {
+// FromFile defines a file-based Source. Compressed files (see Gzip, Zip and Bzip2)
+// are decompressed while reading. Zstd files are not decoded, reading them fails.
+func FromFile(path string, opts ...SourceOpts) Source {
 	return func() (io.ReadCloser, error) {
+		c, err := newSourceConfig(opts)
+		if err != nil {
+			return nil, err
+		}
 		f, err := os.Open(path)
 		if err != nil {
 			return nil, err
 		}
-		return f, nil
+		return decompress(path, f, c)
 	}
 }
 
-var (
-	httpClient *http.Client
-	once       sync.Once
-)
-
-const remoteFetchTimeoutSecs = 15
-
-// Remote fetches the source schema from a remote URL.
-func Remote(url string) Source {
+// Remote fetches the source schema from a remote URL. The response body is streamed,
+// compressed contents (see Gzip, Zip and Bzip2) are decompressed while reading. Zstd
+// contents are not decoded, reading them fails. The request can be configured with
+// RemoteOptions.
+func Remote(url string, opts ...SourceOpts) Source {
 	return func() (io.ReadCloser, error) {
-		once.Do(func() {
-			httpClient = &http.Client{
-				Timeout: remoteFetchTimeoutSecs * time.Second,
-			}
-		})
-		resp, err := httpClient.Get(url)
+		c, err := newSourceConfig(opts)
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
-		body, err := ioutil.ReadAll(resp.Body)
-		return stringReadCloser(string(body)), nil
+		body, err := remote.Get(url, c.remote)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(url, body, c)
 	}
 }
 
@@ -168,18 +323,29 @@ func errorSource() Source {
 	}
 }
 
-// LoadHeaders uses the first line of the CSV as table headers.
-// The header line will be skipped during iteration
+// LoadHeaders uses the first row of the CSV which is not skipped by the row
+// controls (see SkipRows), or the HeaderRows, as table headers. The header row
+// will be skipped during iteration. If the table dialect (set by WithDialect or
+// AutoDetect) has no header and HeaderRows is not set, there are no headers to
+// load and LoadHeaders does nothing. Headers set by a following SetHeaders
+// replace the loaded ones.
 func LoadHeaders() CreationOpts {
 	return func(reader *Table) error {
-		iter, err := reader.Iter()
-		if err != nil {
-			return err
-		}
-		if iter.Next() {
-			reader.headers = iter.Row()
+		reader.loadHeaders = true
+		reader.headers = nil
+		return nil
+	}
+}
+
+// Lenient skips malformed records (see ParseError) instead of stopping the iteration.
+// If report is not nil, it is called with the error of each skipped record. Malformed
+// header rows and encoding errors (see EncodingError) still stop the iteration.
+func Lenient(report func(*ParseError)) CreationOpts {
+	return func(t *Table) error {
+		if report == nil {
+			report = func(*ParseError) {}
 		}
-		reader.skipHeaders = true
+		t.lenient = report
 		return nil
 	}
 }
@@ -197,16 +363,3 @@ func errorOpts(headers ...string) CreationOpts {
 		return fmt.Errorf("error opts")
 	}
 }
-
-// NewWriter creates a writer which appends records to a CSV encoded file.
-//
-// As returned by NewWriter, a csv.Writer writes records terminated by a
-// newline and uses ',' as the field delimiter. The exported fields can be
-// changed to customize the details before the first call to Write or WriteAll.
-//
-// Comma is the field delimiter.
-//
-// If UseCRLF is true, the csv.Writer ends each record with \r\n instead of \n.
-func NewWriter(w io.Writer) *csv.Writer {
-	return csv.NewWriter(w)
-}
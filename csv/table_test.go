@@ -2,11 +2,14 @@ package csv
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/frictionlessdata/tableschema-go/remote"
 	"github.com/matryer/is"
 )
 
@@ -58,6 +61,27 @@ func TestRemote(t *testing.T) {
 		_, err := NewTable(Remote("invalidURL"), LoadHeaders())
 		is.True(err != nil)
 	})
+	t.Run("NotFound", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		_, err := NewTable(Remote(ts.URL), LoadHeaders())
+		_, ok := err.(*remote.StatusError)
+		is.True(ok)
+	})
+	t.Run("Options", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "name\n%s", r.Header.Get("X-Name"))
+		}))
+		defer ts.Close()
+		o := remote.Options{Header: http.Header{"X-Name": {"foo"}}}
+		table, err := NewTable(Remote(ts.URL, RemoteOptions(o)), LoadHeaders())
+		is.NoErr(err)
+		got, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(got, [][]string{{"foo"}})
+	})
 }
 
 func TestLoadHeaders(t *testing.T) {
@@ -113,3 +137,85 @@ func TestSetHeaders(t *testing.T) {
 	is.Equal(want, iter.Row())
 	is.True(!iter.Next())
 }
+
+func TestReadAll_Errors(t *testing.T) {
+	data := []struct {
+		desc string
+		in   string
+		opts []CreationOpts
+		want ParseError
+	}{
+		{"BareQuote", "name,age\nfo\"o,1", nil, ParseError{StartLine: 2, Line: 2, Column: 3, Err: csv.ErrBareQuote}},
+		{"ExtraneousQuote", "name,age\n\"foo\"x,1", nil, ParseError{StartLine: 2, Line: 2, Column: 5, Err: csv.ErrQuote}},
+		{"QuoteNotClosed", "name,age\n\"foo\nbar,1", nil, ParseError{StartLine: 2, Line: 3, Column: 6, Err: csv.ErrQuote}},
+		{"FieldCount", "name,age\nfoo,1\n\nbar", nil, ParseError{StartLine: 4, Line: 4, Err: csv.ErrFieldCount}},
+		{"Escape", "name\nfoo\\", []CreationOpts{WithDialect(Dialect{Delimiter: ",", EscapeChar: "\\"})}, ParseError{StartLine: 2, Line: 2, Column: 4, Err: ErrEscape}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			// Row controls read the records with lines, instead of using encoding/csv.
+			for _, opts := range [][]CreationOpts{d.opts, append(d.opts, SkipBlankRows())} {
+				is := is.New(t)
+				table, err := NewTable(FromString(d.in), opts...)
+				is.NoErr(err)
+				rows, err := table.ReadAll()
+				is.Equal(len(rows), 0)
+				parseErr, ok := err.(*ParseError)
+				is.True(ok)
+				is.Equal(*parseErr, d.want)
+			}
+		})
+	}
+	t.Run("Message", func(t *testing.T) {
+		is := is.New(t)
+		err := &ParseError{StartLine: 2, Line: 3, Column: 5, Err: csv.ErrQuote}
+		is.Equal(err.Error(), `record on line 2; parse error on line 3, column 5: extraneous or missing " in quoted-field`)
+	})
+}
+
+func TestLenient(t *testing.T) {
+	is := is.New(t)
+	in := "name,age\nfoo,1\nb\"ar,2\nbaz\n\"qux\",3\n\"quux\nx,4"
+	var skipped []ParseError
+	table, err := NewTable(FromString(in), LoadHeaders(), Lenient(func(err *ParseError) {
+		skipped = append(skipped, *err)
+	}))
+	is.NoErr(err)
+	rows, err := table.ReadAll()
+	is.NoErr(err)
+	is.Equal(rows, [][]string{{"foo", "1"}, {"qux", "3"}})
+	is.Equal(skipped, []ParseError{
+		{StartLine: 3, Line: 3, Column: 2, Err: csv.ErrBareQuote},
+		{StartLine: 4, Line: 4, Err: csv.ErrFieldCount},
+		{StartLine: 6, Line: 7, Column: 4, Err: csv.ErrQuote},
+	})
+
+	t.Run("NilReport", func(t *testing.T) {
+		is := is.New(t)
+		table, err := NewTable(FromString("name\nfoo\n\"bar"), LoadHeaders(), Lenient(nil))
+		is.NoErr(err)
+		rows, err := table.ReadAll()
+		is.NoErr(err)
+		is.Equal(rows, [][]string{{"foo"}})
+	})
+	t.Run("MalformedHeader", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewTable(FromString("na\"me\nfoo"), LoadHeaders(), Lenient(nil))
+		_, ok := err.(*ParseError)
+		is.True(ok)
+	})
+}
+
+func TestTable_IterContext(t *testing.T) {
+	is := is.New(t)
+	table, err := NewTable(FromString("name\nfoo\nbar"), LoadHeaders())
+	is.NoErr(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	iter, err := table.IterContext(ctx)
+	is.NoErr(err)
+	defer iter.Close()
+	is.True(iter.Next()) // want first row
+	cancel()
+	is.True(!iter.Next()) // iteration must stop once canceled
+	is.Equal(iter.Err(), context.Canceled)
+}
@@ -0,0 +1,209 @@
+package csv
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Writer writes records to a CSV encoded file following a dialect, see NewDialectWriter.
+type Writer struct {
+	// Set if the dialect is supported by encoding/csv, w being set otherwise.
+	csv      *csv.Writer
+	w        *bufio.Writer
+	dialect  *Dialect
+	chars    dialectChars
+	encoding string
+	// Set if the output is compressed.
+	compression string
+	gzip        *gzip.Writer
+	err         error
+}
+
+// WriterOpts defines functional options for creating Writers.
+type WriterOpts func(w *Writer)
+
+// NewWriter creates a writer which appends records to a CSV encoded file.
+//
+// As returned by NewWriter, a csv.Writer writes records terminated by a
+// newline and uses ',' as the field delimiter. The exported fields can be
+// changed to customize the details before the first call to Write or WriteAll.
+//
+// Comma is the field delimiter.
+//
+// If UseCRLF is true, the csv.Writer ends each record with \r\n instead of \n.
+func NewWriter(w io.Writer) *csv.Writer {
+	return csv.NewWriter(w)
+}
+
+// NewDialectWriter creates a writer which appends records to a CSV encoded file, as
+// configured by the options. Records are formatted as by NewWriter unless WriterDialect
+// is used.
+func NewDialectWriter(w io.Writer, opts ...WriterOpts) *Writer {
+	ret := &Writer{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	if ret.compression == Gzip {
+		ret.gzip = gzip.NewWriter(w)
+		w = ret.gzip
+	}
+	if ret.encoding != "" && ret.encoding != UTF8 {
+		w = newEncodingWriter(w, ret.encoding)
+	}
+	switch {
+	case ret.dialect == nil || ret.err != nil:
+		ret.csv = csv.NewWriter(w)
+	case ret.chars.standard(*ret.dialect):
+		ret.csv = csv.NewWriter(w)
+		ret.csv.Comma = ret.chars.delimiter
+		ret.csv.UseCRLF = ret.dialect.LineTerminator == "" || ret.dialect.LineTerminator == "\r\n"
+	default:
+		ret.w = bufio.NewWriter(w)
+	}
+	return ret
+}
+
+// WriterDialect sets how records are formatted. An invalid dialect is reported by
+// Write and WriteAll.
+func WriterDialect(d Dialect) WriterOpts {
+	return func(w *Writer) {
+		w.dialect = &d
+		c, err := d.chars()
+		if err != nil {
+			w.err = err
+			return
+		}
+		w.chars = c
+	}
+}
+
+// WriterCompression sets the compression of the written file. Only Gzip is supported,
+// an unsupported compression is reported by Write and WriteAll. Compressed files are
+// complete once the writer is closed.
+func WriterCompression(name string) WriterOpts {
+	return func(w *Writer) {
+		if name != Gzip {
+			w.err = fmt.Errorf("%s compression is not supported by writers", name)
+			return
+		}
+		w.compression = name
+	}
+}
+
+// Write writes a single record along with any necessary quoting.
+func (w *Writer) Write(record []string) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.csv != nil {
+		return w.csv.Write(record)
+	}
+	for i, field := range record {
+		if i > 0 {
+			if _, err := w.w.WriteRune(w.chars.delimiter); err != nil {
+				return err
+			}
+		}
+		if err := w.writeField(field); err != nil {
+			return err
+		}
+	}
+	terminator := w.dialect.LineTerminator
+	if terminator == "" {
+		terminator = DefaultDialect.LineTerminator
+	}
+	_, err := w.w.WriteString(terminator)
+	return err
+}
+
+// writeField writes a field, quoting it if it contains special characters. Quote and
+// escape characters inside quoted fields are escaped using the dialect rules.
+func (w *Writer) writeField(field string) error {
+	if !w.fieldNeedsQuotes(field) {
+		_, err := w.w.WriteString(field)
+		return err
+	}
+	w.w.WriteRune(w.chars.quote)
+	for _, r := range field {
+		switch {
+		case r == w.chars.quote && w.dialect.DoubleQuote:
+			w.w.WriteRune(r)
+		case r == w.chars.quote || (r == w.chars.escape && w.chars.escape != 0):
+			if w.chars.escape == 0 {
+				return fmt.Errorf("can not write %q: quote characters need doubleQuote or escapeChar", field)
+			}
+			w.w.WriteRune(w.chars.escape)
+		}
+		w.w.WriteRune(r)
+	}
+	_, err := w.w.WriteRune(w.chars.quote)
+	return err
+}
+
+func (w *Writer) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+	if w.chars.comment != 0 && strings.IndexRune(field, w.chars.comment) == 0 {
+		return true
+	}
+	for _, r := range field {
+		if r == w.chars.delimiter || r == w.chars.quote || r == '\r' || r == '\n' || (r == w.chars.escape && w.chars.escape != 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteAll writes multiple records using Write and then calls Flush.
+func (w *Writer) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// Flush writes any buffered data to the underlying io.Writer. To check if an error
+// occurred during the Flush, call Error.
+func (w *Writer) Flush() {
+	if w.csv != nil {
+		w.csv.Flush()
+	} else if err := w.w.Flush(); err != nil && w.err == nil {
+		w.err = err
+	}
+	if w.gzip != nil {
+		if err := w.gzip.Flush(); err != nil && w.err == nil {
+			w.err = err
+		}
+	}
+}
+
+// Close flushes the writer and completes the compressed stream, if any. It does not close
+// the underlying io.Writer.
+func (w *Writer) Close() error {
+	w.Flush()
+	if w.gzip != nil {
+		if err := w.gzip.Close(); err != nil && w.err == nil {
+			w.err = err
+		}
+	}
+	return w.Error()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *Writer) Error() error {
+	if w.err != nil || w.csv == nil {
+		return w.err
+	}
+	return w.csv.Error()
+}
@@ -0,0 +1,207 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Extensions of the files holding the cached bodies and their metadata.
+const (
+	bodyExt  = ".body"
+	entryExt = ".json"
+)
+
+// Cache stores remote responses in a directory, keyed by URL. Cached responses are
+// revalidated using their ETag and Last-Modified headers, so unchanged responses are
+// not downloaded again.
+type Cache struct {
+	// Dir is the directory of the cache, which is created if needed.
+	Dir string
+	// MaxAge is how long cached responses are used without being revalidated. Responses
+	// are revalidated every time if 0.
+	MaxAge time.Duration
+	// Offline uses the cached responses without sending requests. URLs which are not
+	// cached return an error.
+	Offline bool
+}
+
+// NewCache creates a cache storing responses in the directory.
+func NewCache(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// CacheEntry describes a cached response.
+type CacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Size         int64  `json:"size"`
+	// Fetched is when the response was last fetched or revalidated.
+	Fetched time.Time `json:"fetched"`
+}
+
+// Entries returns the cached responses, sorted by URL.
+func (c *Cache) Entries() ([]CacheEntry, error) {
+	paths, err := filepath.Glob(filepath.Join(c.Dir, "*"+entryExt))
+	if err != nil {
+		return nil, err
+	}
+	var entries []CacheEntry
+	for _, p := range paths {
+		e, err := readEntry(p)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].URL < entries[j].URL })
+	return entries, nil
+}
+
+// Remove removes the cached response of the URL, if any.
+func (c *Cache) Remove(url string) error {
+	for _, p := range []string{c.path(url, entryExt), c.path(url, bodyExt)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Clear removes all cached responses. Other files of the cache directory are kept.
+func (c *Cache) Clear() error {
+	for _, ext := range []string{entryExt, bodyExt} {
+		paths, err := filepath.Glob(filepath.Join(c.Dir, "*"+ext))
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// path returns the path of the cache file of the URL with the extension.
+func (c *Cache) path(url, ext string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+ext)
+}
+
+func readEntry(path string) (*CacheEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var e CacheEntry
+	if err := json.NewDecoder(f).Decode(&e); err != nil {
+		return nil, fmt.Errorf("invalid cache entry %s: %v", path, err)
+	}
+	return &e, nil
+}
+
+// get returns the cached response of the URL, fetching or revalidating it if needed.
+func (c *Cache) get(url string, o Options) (io.ReadCloser, error) {
+	entry, err := readEntry(c.path(url, entryExt))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if entry != nil {
+		if _, err := os.Stat(c.path(url, bodyExt)); err != nil {
+			entry = nil
+		}
+	}
+	switch {
+	case entry == nil && c.Offline:
+		return nil, fmt.Errorf("error fetching %s: not cached and the cache is offline", url)
+	case entry != nil && (c.Offline || time.Since(entry.Fetched) < c.MaxAge):
+		return os.Open(c.path(url, bodyExt))
+	}
+	header := http.Header{}
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	resp, err := fetch(url, o, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		if entry == nil {
+			return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+		entry.Fetched = time.Now()
+		if err := c.writeEntry(entry); err != nil {
+			return nil, err
+		}
+		return os.Open(c.path(url, bodyExt))
+	}
+	entry = &CacheEntry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Fetched:      time.Now(),
+	}
+	if entry.Size, err = c.writeBody(url, limitBody(resp.Body, url, o.MaxBodySize)); err != nil {
+		return nil, err
+	}
+	if err := c.writeEntry(entry); err != nil {
+		return nil, err
+	}
+	return os.Open(c.path(url, bodyExt))
+}
+
+func (c *Cache) writeBody(url string, body io.Reader) (int64, error) {
+	var size int64
+	err := c.writeFile(c.path(url, bodyExt), func(w io.Writer) error {
+		var err error
+		size, err = io.Copy(w, body)
+		return err
+	})
+	return size, err
+}
+
+func (c *Cache) writeEntry(e *CacheEntry) error {
+	return c.writeFile(c.path(e.URL, entryExt), func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(e)
+	})
+}
+
+// writeFile writes the file using a temporary file, so readers never see a partially
+// written file.
+func (c *Cache) writeFile(path string, write func(io.Writer) error) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(c.Dir, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
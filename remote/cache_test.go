@@ -0,0 +1,137 @@
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// cacheServer serves a body with an ETag, counting the requests and the responses
+// which are not modified.
+type cacheServer struct {
+	body                  string
+	requests, notModified int
+}
+
+func (s *cacheServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	etag := fmt.Sprintf("%q", s.body)
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	fmt.Fprint(w, s.body)
+}
+
+func readAll(t *testing.T, url string, o Options) string {
+	body, err := Get(url, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tableschema-go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	newCache := func(t *testing.T) *Cache {
+		return NewCache(filepath.Join(dir, t.Name()))
+	}
+	t.Run("Revalidate", func(t *testing.T) {
+		is := is.New(t)
+		s := &cacheServer{body: "foo"}
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+		o := Options{Cache: newCache(t)}
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		is.Equal(s.requests, 2)
+		is.Equal(s.notModified, 1)
+
+		s.body = "bar"
+		is.Equal(readAll(t, ts.URL, o), "bar")
+		is.Equal(s.requests, 3)
+	})
+	t.Run("MaxAge", func(t *testing.T) {
+		is := is.New(t)
+		s := &cacheServer{body: "foo"}
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+		o := Options{Cache: newCache(t)}
+		o.Cache.MaxAge = time.Hour
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		s.body = "bar"
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		is.Equal(s.requests, 1)
+	})
+	t.Run("Offline", func(t *testing.T) {
+		is := is.New(t)
+		s := &cacheServer{body: "foo"}
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+		o := Options{Cache: newCache(t)}
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		ts.Close()
+
+		o.Cache.Offline = true
+		is.Equal(readAll(t, ts.URL, o), "foo")
+		_, err := Get(ts.URL+"/other", o)
+		is.True(err != nil) // not cached
+	})
+	t.Run("Errors", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		c := newCache(t)
+		_, err := Get(ts.URL, Options{Cache: c})
+		_, ok := err.(*StatusError)
+		is.True(ok)
+		entries, err := c.Entries()
+		is.NoErr(err)
+		is.Equal(len(entries), 0) // errors are not cached
+	})
+	t.Run("EntriesAndClear", func(t *testing.T) {
+		is := is.New(t)
+		s := &cacheServer{body: "foo"}
+		ts := httptest.NewServer(s)
+		defer ts.Close()
+		c := newCache(t)
+		o := Options{Cache: c}
+		readAll(t, ts.URL+"/a", o)
+		readAll(t, ts.URL+"/b", o)
+		entries, err := c.Entries()
+		is.NoErr(err)
+		is.Equal(len(entries), 2)
+		is.Equal(entries[0].URL, ts.URL+"/a")
+		is.Equal(entries[0].ETag, `"foo"`)
+		is.Equal(entries[0].Size, int64(3))
+
+		is.NoErr(c.Remove(ts.URL + "/a"))
+		entries, err = c.Entries()
+		is.NoErr(err)
+		is.Equal(len(entries), 1)
+		is.Equal(entries[0].URL, ts.URL+"/b")
+
+		is.NoErr(c.Clear())
+		entries, err = c.Entries()
+		is.NoErr(err)
+		is.Equal(len(entries), 0)
+	})
+}
@@ -0,0 +1,195 @@
+// Package remote fetches schemas and tables from remote URLs, as done by
+// schema.LoadRemote and csv.Remote.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Timeouts of the default client, for connecting and then for receiving the
+	// response headers. Reading the body has no timeout, so large tables can be
+	// streamed; Options.Context can be used to bound it.
+	remoteFetchTimeoutSecs = 15
+	remoteDialTimeoutSecs  = 10
+	// Delay before the first retry if Options.Backoff is not set.
+	defaultBackoff = 500 * time.Millisecond
+)
+
+var (
+	defaultClient *http.Client
+	once          sync.Once
+)
+
+// Options configures how remote URLs are fetched. The zero value fetches URLs once, with
+// a 15 seconds timeout to receive the response headers, and reports non-2xx responses as
+// a *StatusError.
+type Options struct {
+	// Context of the requests, context.Background() if nil. Canceling it stops the
+	// fetching, including reading the response body and waiting for retries.
+	Context context.Context
+	// Client sends the requests. If nil, a client with a 10 seconds connection timeout and
+	// a 15 seconds timeout to receive the response headers is used. Reading the response
+	// body is not limited in time.
+	Client *http.Client
+	// Header is added to the requests, e.g. an Authorization header.
+	Header http.Header
+	// Retries is the number of times a request is retried if it fails because of a
+	// network error or a 429 or 5xx response.
+	Retries int
+	// Backoff is the delay before the first retry, which doubles on each retry. If a
+	// response has a Retry-After header in seconds, it is waited instead. The default
+	// is 500ms.
+	Backoff time.Duration
+	// MaxBodySize is the maximum number of bytes read from the response body, there
+	// is no limit if 0.
+	MaxBodySize int64
+	// Cache stores the responses on disk, so they are not fetched again. There is no
+	// cache if nil.
+	Cache *Cache
+}
+
+// StatusError reports a response which status code is not 2xx.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("error fetching %s: %s", e.URL, e.Status)
+}
+
+// Get fetches the URL and returns the response body, which the caller must close.
+func Get(url string, o Options) (io.ReadCloser, error) {
+	if o.Cache != nil {
+		return o.Cache.get(url, o)
+	}
+	resp, err := fetch(url, o, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return limitBody(resp.Body, url, o.MaxBodySize), nil
+}
+
+// fetch sends the request with the options header and the extra header, retrying as
+// configured, and returns 2xx and 304 responses.
+func fetch(url string, o Options, extra http.Header) (*http.Response, error) {
+	ctx := o.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := o.Client
+	if client == nil {
+		once.Do(func() {
+			defaultClient = &http.Client{
+				Transport: &http.Transport{
+					Proxy: http.ProxyFromEnvironment,
+					DialContext: (&net.Dialer{
+						Timeout:   remoteDialTimeoutSecs * time.Second,
+						KeepAlive: 30 * time.Second,
+					}).DialContext,
+					TLSHandshakeTimeout:   remoteDialTimeoutSecs * time.Second,
+					ResponseHeaderTimeout: remoteFetchTimeoutSecs * time.Second,
+					MaxIdleConns:          100,
+					IdleConnTimeout:       90 * time.Second,
+				},
+			}
+		})
+		client = defaultClient
+	}
+	backoff := o.Backoff
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	for retry := 0; ; retry++ {
+		resp, err := get(ctx, client, url, o.Header, extra)
+		if err == nil && (resp.StatusCode >= 200 && resp.StatusCode < 300 || resp.StatusCode == http.StatusNotModified) {
+			return resp, nil
+		}
+		wait := backoff << uint(retry)
+		if err == nil {
+			// Draining the body, so the connection can be reused.
+			io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			err = &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+			if !retryable(resp.StatusCode) {
+				return nil, err
+			}
+			if secs, convErr := time.ParseDuration(resp.Header.Get("Retry-After") + "s"); convErr == nil && secs >= 0 {
+				wait = secs
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if retry >= o.Retries {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func get(ctx context.Context, client *http.Client, url string, headers ...http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range headers {
+		for k, v := range h {
+			req.Header[k] = v
+		}
+	}
+	return client.Do(req.WithContext(ctx))
+}
+
+// retryable reports whether requests failing with the status code are retried.
+func retryable(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// limitBody limits the body to max bytes, if max is positive.
+func limitBody(body io.ReadCloser, url string, max int64) io.ReadCloser {
+	if max <= 0 {
+		return body
+	}
+	return &limitedBody{ReadCloser: body, url: url, left: max}
+}
+
+// limitedBody reports an error if more than left bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	url  string
+	left int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.left < 0 {
+		return 0, fmt.Errorf("error fetching %s: body exceeds the maximum size", b.url)
+	}
+	// Reading one more byte than allowed tells whether the body is too large.
+	if int64(len(p)) > b.left+1 {
+		p = p[:b.left+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.left -= int64(n)
+	if b.left < 0 {
+		return n + int(b.left), fmt.Errorf("error fetching %s: body exceeds the maximum size", b.url)
+	}
+	return n, err
+}
@@ -0,0 +1,130 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("Header", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "foo")
+		}))
+		defer ts.Close()
+		_, err := Get(ts.URL, Options{})
+		statusErr, ok := err.(*StatusError)
+		is.True(ok)
+		is.Equal(statusErr.StatusCode, http.StatusUnauthorized)
+
+		body, err := Get(ts.URL, Options{Header: http.Header{"Authorization": {"Bearer token"}}})
+		is.NoErr(err)
+		defer body.Close()
+		got, err := ioutil.ReadAll(body)
+		is.NoErr(err)
+		is.Equal(string(got), "foo")
+	})
+	t.Run("DefaultClient", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "foo")
+		}))
+		defer ts.Close()
+		body, err := Get(ts.URL, Options{})
+		is.NoErr(err)
+		body.Close()
+		is.Equal(defaultClient.Timeout, time.Duration(0)) // reading the body must not time out
+		transport, ok := defaultClient.Transport.(*http.Transport)
+		is.True(ok)
+		is.True(transport.ResponseHeaderTimeout > 0)
+	})
+	t.Run("Retries", func(t *testing.T) {
+		data := []struct {
+			desc     string
+			status   int
+			retries  int
+			requests int
+			ok       bool
+		}{
+			{"ServerError", http.StatusServiceUnavailable, 2, 3, true},
+			{"TooManyRequests", http.StatusTooManyRequests, 1, 2, false},
+			{"NotFound", http.StatusNotFound, 2, 1, false},
+			{"NoRetries", http.StatusInternalServerError, 0, 1, false},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				requests := 0
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests++
+					if requests < 3 {
+						w.WriteHeader(d.status)
+						return
+					}
+					fmt.Fprint(w, "foo")
+				}))
+				defer ts.Close()
+				body, err := Get(ts.URL, Options{Retries: d.retries, Backoff: time.Millisecond})
+				is.Equal(requests, d.requests)
+				is.Equal(err == nil, d.ok)
+				if err == nil {
+					body.Close()
+				}
+			})
+		}
+	})
+	t.Run("MaxBodySize", func(t *testing.T) {
+		data := []struct {
+			desc string
+			max  int64
+			ok   bool
+		}{
+			{"Smaller", 4, true},
+			{"Equal", 3, true},
+			{"Larger", 2, false},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, "foo")
+				}))
+				defer ts.Close()
+				body, err := Get(ts.URL, Options{MaxBodySize: d.max})
+				is.NoErr(err)
+				defer body.Close()
+				got, err := ioutil.ReadAll(body)
+				is.Equal(err == nil, d.ok)
+				is.True(strings.HasPrefix("foo", string(got)))
+			})
+		}
+	})
+	t.Run("Context", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := Get(ts.URL, Options{Context: ctx, Retries: 5, Backoff: time.Hour})
+		is.Equal(err, context.DeadlineExceeded)
+	})
+	t.Run("InvalidURL", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Get("invalidURL", Options{})
+		is.True(err != nil)
+	})
+}
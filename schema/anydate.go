@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateOrder defines how the "any" format interprets numeric dates in which
+// both the day and the month come before the year, for instance "01/02/2017".
+type DateOrder string
+
+// Valid date orders.
+const (
+	// UnspecifiedDateOrder does not assume any order. Values which can be read
+	// both ways (e.g. "01/02/2017") result in an *AmbiguousDateError.
+	UnspecifiedDateOrder DateOrder = ""
+	// DayFirst reads "01/02/2017" as 1st of February 2017.
+	DayFirst DateOrder = "dayFirst"
+	// MonthFirst reads "01/02/2017" as 2nd of January 2017.
+	MonthFirst DateOrder = "monthFirst"
+)
+
+// AmbiguousDateError is returned when a value decoded using the "any" format can
+// be interpreted both as day-first and month-first and the field has no DateOrder set.
+type AmbiguousDateError struct {
+	Value      string
+	DayFirst   time.Time
+	MonthFirst time.Time
+}
+
+func (e *AmbiguousDateError) Error() string {
+	return fmt.Sprintf("ambiguous date:\"%s\" could be %s (day first) or %s (month first), please set the field dateOrder",
+		e.Value, e.DayFirst.Format("2006-01-02"), e.MonthFirst.Format("2006-01-02"))
+}
+
+// UnknownTimeZoneError is returned when a value decoded using the "any" format has a
+// time zone abbreviation whose UTC offset is not known, e.g. "PST". Only UTC, GMT and
+// the abbreviations of the field location are known.
+type UnknownTimeZoneError struct {
+	Value string
+	Zone  string
+}
+
+func (e *UnknownTimeZoneError) Error() string {
+	return fmt.Sprintf("unknown time zone:\"%s\" in \"%s\", please use a numeric UTC offset", e.Zone, e.Value)
+}
+
+var (
+	// Numeric dates, e.g. 31/12/2017, 12-31-17 or 31.12.2017. The separator must be the same.
+	anyDayMonthYearRegexp = regexp.MustCompile(`^(\d{1,2})([/.-])(\d{1,2})([/.-])(\d{4}|\d{2})$`)
+	// Numeric dates starting with the year, e.g. 2017.12.31 or 2017/12/31.
+	anyYearMonthDayRegexp = regexp.MustCompile(`^(\d{4})([/.-])(\d{1,2})([/.-])(\d{1,2})$`)
+)
+
+// Layouts tried by the "any" format, ranked by how common they are.
+var (
+	anyDateLayouts = []string{
+		"20060102",
+		"2 Jan 2006",
+		"2 January 2006",
+		"2-Jan-2006",
+		"2-Jan-06",
+		"2 Jan 06",
+		"Jan 2 2006",
+		"Jan 2, 2006",
+		"January 2 2006",
+		"January 2, 2006",
+		"2006-Jan-02",
+		"Mon, 2 Jan 2006",
+		"Mon Jan 2 2006",
+		"Monday, 2 January 2006",
+		"Monday, January 2, 2006",
+	}
+	anyTimeLayouts = []string{
+		"15:04:05",
+		"15:04",
+		"15:04:05Z07:00",
+		"15:04:05Z0700",
+		"15:04:05 Z07:00",
+		"15:04:05 -0700",
+		"15:04:05 MST",
+		"15:04Z07:00",
+		"150405",
+		"3:04:05 PM",
+		"3:04:05PM",
+		"3:04 PM",
+		"3:04PM",
+		"3 PM",
+		"3PM",
+	}
+	anyDateTimeLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC1123Z,
+		time.RFC1123,
+		time.RFC850,
+		time.RFC822Z,
+		time.RFC822,
+		time.ANSIC,
+		time.UnixDate,
+		time.RubyDate,
+	}
+)
+
+// decodeAnyTime decodes value trying a ranked list of common layouts for the
+// passed-in temporal field type (DateType, TimeType or DateTimeType). Times and
+// datetimes without UTC offset are interpreted in the passed-in location.
+func decodeAnyTime(fieldType string, order DateOrder, loc *time.Location, value string) (time.Time, error) {
+	v := strings.TrimSpace(value)
+	switch fieldType {
+	case DateType:
+		return parseAnyDate(order, v)
+	case TimeType:
+		return parseAnyLayout(anyTimeLayouts, v, loc)
+	case DateTimeType:
+		return parseAnyDateTime(order, loc, v)
+	}
+	return time.Time{}, fmt.Errorf("any format is not supported by type:%s", fieldType)
+}
+
+func parseAnyDate(order DateOrder, value string) (time.Time, error) {
+	if m := anyYearMonthDayRegexp.FindStringSubmatch(value); m != nil && m[2] == m[4] {
+		y, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[3])
+		d, _ := strconv.Atoi(m[5])
+		if t, ok := buildDate(y, month, d); ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid date:\"%s\"", value)
+	}
+	if m := anyDayMonthYearRegexp.FindStringSubmatch(value); m != nil && m[2] == m[4] {
+		return parseNumericDate(order, value, m[1], m[3], m[5])
+	}
+	return parseAnyLayout(anyDateLayouts, value, time.UTC)
+}
+
+func parseNumericDate(order DateOrder, value, first, second, year string) (time.Time, error) {
+	a, _ := strconv.Atoi(first)
+	b, _ := strconv.Atoi(second)
+	y, _ := strconv.Atoi(year)
+	if len(year) == 2 {
+		// Following POSIX strptime %y: 69-99 are in the 20th century and 00-68 in the 21st.
+		if y < 69 {
+			y += 2000
+		} else {
+			y += 1900
+		}
+	}
+	dayFirst, dayFirstOK := buildDate(y, b, a)
+	monthFirst, monthFirstOK := buildDate(y, a, b)
+	switch {
+	case dayFirstOK && monthFirstOK && !dayFirst.Equal(monthFirst):
+		switch order {
+		case DayFirst:
+			return dayFirst, nil
+		case MonthFirst:
+			return monthFirst, nil
+		}
+		return time.Time{}, &AmbiguousDateError{Value: value, DayFirst: dayFirst, MonthFirst: monthFirst}
+	case dayFirstOK:
+		return dayFirst, nil
+	case monthFirstOK:
+		return monthFirst, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date:\"%s\"", value)
+}
+
+// buildDate returns the date and true if year, month and day represent a valid
+// date (i.e. time.Date does not need to normalize it).
+func buildDate(year, month, day int) (time.Time, bool) {
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return t, t.Year() == year && int(t.Month()) == month && t.Day() == day
+}
+
+func parseAnyDateTime(order DateOrder, loc *time.Location, value string) (time.Time, error) {
+	t, err := parseAnyLayout(anyDateTimeLayouts, value, loc)
+	if _, ok := err.(*UnknownTimeZoneError); ok || err == nil {
+		return t, err
+	}
+	// Trying to split the value into a date and a time, for instance "31 Dec 2017 13:45" or
+	// "12/31/2017T13:45:00Z". The first split point which results in a valid date is used.
+	for i, r := range value {
+		if r != ' ' && r != 'T' {
+			continue
+		}
+		d, err := parseAnyDate(order, value[:i])
+		if err != nil {
+			if _, ok := err.(*AmbiguousDateError); ok {
+				return time.Time{}, err
+			}
+			continue
+		}
+		t, err := parseAnyLayout(anyTimeLayouts, strings.TrimSpace(value[i+1:]), loc)
+		if _, ok := err.(*UnknownTimeZoneError); ok {
+			return time.Time{}, err
+		}
+		if err != nil {
+			continue
+		}
+		return time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+	}
+	// Dates without time are considered midnight.
+	if d, err := parseAnyDate(order, value); err == nil {
+		return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc), nil
+	} else if _, ok := err.(*AmbiguousDateError); ok {
+		return time.Time{}, err
+	}
+	return time.Time{}, fmt.Errorf("invalid datetime:\"%s\" does not match any known layout", value)
+}
+
+func parseAnyLayout(layouts []string, value string, loc *time.Location) (time.Time, error) {
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l, value, loc); err == nil {
+			if strings.Contains(l, "MST") && !knownZone(t, loc) {
+				name, _ := t.Zone()
+				return time.Time{}, &UnknownTimeZoneError{Value: value, Zone: name}
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid value:\"%s\" does not match any known layout", value)
+}
+
+// knownZone reports whether the zone abbreviation of a time parsed in the location has
+// a known UTC offset. time.ParseInLocation reads unknown abbreviations with a zero offset.
+func knownZone(t time.Time, loc *time.Location) bool {
+	name, _ := t.Zone()
+	return t.Location() == loc || name == "UTC" || name == "GMT"
+}
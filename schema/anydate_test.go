@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeAnyTime(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc      string
+			fieldType string
+			order     DateOrder
+			value     string
+			want      time.Time
+		}{
+			{"ISO", DateType, UnspecifiedDateOrder, "2017-12-31", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"YearFirstDots", DateType, UnspecifiedDateOrder, "2017.12.31", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"Compact", DateType, UnspecifiedDateOrder, "20171231", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"MonthFirstUnambiguous", DateType, UnspecifiedDateOrder, "12/31/2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"DayFirstUnambiguous", DateType, UnspecifiedDateOrder, "31-12-2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"SameDayAndMonth", DateType, UnspecifiedDateOrder, "05/05/2017", time.Date(2017, 5, 5, 0, 0, 0, 0, time.UTC)},
+			{"DayFirst", DateType, DayFirst, "01/02/2017", time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{"MonthFirst", DateType, MonthFirst, "01/02/2017", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{"PreferenceFallback", DateType, DayFirst, "12/31/2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"TwoDigitYear", DateType, DayFirst, "01.02.17", time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{"DayMonthName", DateType, UnspecifiedDateOrder, "31 Dec 2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"MonthNameDay", DateType, UnspecifiedDateOrder, "December 31, 2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"LowerCaseMonth", DateType, UnspecifiedDateOrder, "31-dec-2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"Time24h", TimeType, UnspecifiedDateOrder, "13:45", time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)},
+			{"Time12h", TimeType, UnspecifiedDateOrder, "1:45:10 PM", time.Date(0, 1, 1, 13, 45, 10, 0, time.UTC)},
+			{"TimeFraction", TimeType, UnspecifiedDateOrder, "13:45:10.5", time.Date(0, 1, 1, 13, 45, 10, 500000000, time.UTC)},
+			{"RFC3339", DateTimeType, UnspecifiedDateOrder, "2017-12-31T13:45:00+01:00", time.Date(2017, 12, 31, 12, 45, 0, 0, time.UTC)},
+			{"RFC1123", DateTimeType, UnspecifiedDateOrder, "Sun, 31 Dec 2017 13:45:00 GMT", time.Date(2017, 12, 31, 13, 45, 0, 0, time.UTC)},
+			{"NaiveISO", DateTimeType, UnspecifiedDateOrder, "2017-12-31T13:45:00", time.Date(2017, 12, 31, 13, 45, 0, 0, time.UTC)},
+			{"NumericDateAndTime", DateTimeType, MonthFirst, "01/02/2017 1:45 PM", time.Date(2017, 1, 2, 13, 45, 0, 0, time.UTC)},
+			{"NamedDateAndTime", DateTimeType, UnspecifiedDateOrder, "31 Dec 2017 13:45:00 -0200", time.Date(2017, 12, 31, 15, 45, 0, 0, time.UTC)},
+			{"DateOnly", DateTimeType, UnspecifiedDateOrder, "2017/12/31", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := decodeAnyTime(d.fieldType, d.order, time.UTC, d.value)
+				is.NoErr(err)
+				is.True(got.Equal(d.want)) // times must be equal
+			})
+		}
+	})
+	t.Run("Ambiguous", func(t *testing.T) {
+		data := []struct {
+			desc      string
+			fieldType string
+			value     string
+		}{
+			{"Date", DateType, "01/02/2017"},
+			{"DateTime", DateTimeType, "01/02/2017 10:00"},
+			{"DateTimeDateOnly", DateTimeType, "01-02-2017"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeAnyTime(d.fieldType, UnspecifiedDateOrder, time.UTC, d.value)
+				ambErr, ok := err.(*AmbiguousDateError)
+				is.True(ok) // want *AmbiguousDateError
+				is.Equal(ambErr.DayFirst, time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC))
+				is.Equal(ambErr.MonthFirst, time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC))
+			})
+		}
+	})
+	t.Run("UnknownTimeZone", func(t *testing.T) {
+		data := []struct {
+			desc      string
+			fieldType string
+			value     string
+		}{
+			{"RFC1123PST", DateTimeType, "Mon, 02 Jan 2006 15:04:05 PST"},
+			{"RFC1123CET", DateTimeType, "Mon, 02 Jan 2006 15:04:05 CET"},
+			{"UnixDate", DateTimeType, "Mon Jan  2 15:04:05 PST 2006"},
+			{"DateAndTime", DateTimeType, "31 Dec 2017 13:45:00 PST"},
+			{"Time", TimeType, "10:00:00 EST"},
+			{"GMTOffset", DateTimeType, "Mon, 02 Jan 2006 15:04:05 GMT+3"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeAnyTime(d.fieldType, UnspecifiedDateOrder, time.UTC, d.value)
+				_, ok := err.(*UnknownTimeZoneError)
+				is.True(ok) // want *UnknownTimeZoneError
+			})
+		}
+	})
+	t.Run("LocationZone", func(t *testing.T) {
+		is := is.New(t)
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip(err)
+		}
+		got, err := decodeAnyTime(DateTimeType, UnspecifiedDateOrder, ny, "Mon, 02 Jan 2006 15:04:05 EST")
+		is.NoErr(err)
+		is.True(got.Equal(time.Date(2006, 1, 2, 20, 4, 5, 0, time.UTC))) // EST is the location zone
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc      string
+			fieldType string
+			value     string
+		}{
+			{"InvalidDate", DateType, "foo"},
+			{"InvalidDay", DateType, "31/31/2017"},
+			{"MixedSeparators", DateType, "31/12-2017"},
+			{"InvalidYearFirst", DateType, "2017-02-30"},
+			{"InvalidTime", TimeType, "25:00"},
+			{"InvalidDateTime", DateTimeType, "2017-12-31 foo"},
+			{"UnsupportedType", YearType, "2017"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeAnyTime(d.fieldType, UnspecifiedDateOrder, time.UTC, d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
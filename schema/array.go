@@ -5,7 +5,7 @@ import (
 	"fmt"
 )
 
-func castArray(value string) (interface{}, error) {
+func castArray(value string, c Constraints) (interface{}, error) {
 	var obj interface{}
 	if err := json.Unmarshal([]byte(value), &obj); err != nil {
 		return nil, err
@@ -14,5 +14,8 @@ func castArray(value string) (interface{}, error) {
 	if !ok {
 		return nil, fmt.Errorf("%s is not an JSON array", value)
 	}
+	if err := checkJSONConstraints(arr, len(arr), c); err != nil {
+		return nil, err
+	}
 	return arr, nil
 }
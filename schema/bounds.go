@@ -0,0 +1,287 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Names of the constraints which bound ordered types.
+const (
+	minimumConstraint          = "minimum"
+	maximumConstraint          = "maximum"
+	exclusiveMinimumConstraint = "exclusiveMinimum"
+	exclusiveMaximumConstraint = "exclusiveMaximum"
+)
+
+// Relative bounds of date and datetime fields, e.g. "now", "now-P1Y" or "now+PT12H".
+var relativeBoundRegexp = regexp.MustCompile(`^now(?:([-+])(P.+))?$`)
+
+// ordering parses and compares the values of an ordered type.
+type ordering struct {
+	// Identifies how bounds are parsed, so parsed bounds are only reused for the same
+	// type, format and location.
+	key     boundsKey
+	parse   func(string) (interface{}, error)
+	compare func(a, b interface{}) (int, error)
+	// now returns the value of a bound relative to the current time. It is nil for types
+	// which do not support relative bounds.
+	now func(offset Duration) interface{}
+}
+
+type boundsKey struct {
+	typ    string
+	format string
+	order  DateOrder
+	loc    *time.Location
+	// Unparsed constraints.
+	minimum, maximum, exclusiveMinimum, exclusiveMaximum string
+}
+
+// bound is a parsed minimum or maximum constraint.
+type bound struct {
+	constraint string
+	raw        string
+	value      interface{}
+	// Offset from the current time, if the bound is relative.
+	relative *Duration
+}
+
+// bounds holds the parsed bound constraints of a field.
+type bounds struct {
+	key    boundsKey
+	bounds []bound
+}
+
+func (o ordering) withConstraints(c Constraints) boundsKey {
+	k := o.key
+	k.minimum, k.maximum = c.Minimum, c.Maximum
+	k.exclusiveMinimum, k.exclusiveMaximum = c.ExclusiveMinimum, c.ExclusiveMaximum
+	return k
+}
+
+func (c Constraints) hasBounds() bool {
+	return c.Minimum != "" || c.Maximum != "" || c.ExclusiveMinimum != "" || c.ExclusiveMaximum != ""
+}
+
+// parseBounds parses the bound constraints which are set.
+func parseBounds(c Constraints, o ordering) (*bounds, error) {
+	b := &bounds{key: o.withConstraints(c)}
+	for _, bc := range []struct{ constraint, raw string }{
+		{minimumConstraint, c.Minimum},
+		{maximumConstraint, c.Maximum},
+		{exclusiveMinimumConstraint, c.ExclusiveMinimum},
+		{exclusiveMaximumConstraint, c.ExclusiveMaximum},
+	} {
+		if bc.raw == "" {
+			continue
+		}
+		parsed := bound{constraint: bc.constraint, raw: bc.raw}
+		if m := relativeBoundRegexp.FindStringSubmatch(bc.raw); m != nil && o.now != nil {
+			var offset Duration
+			if m[2] != "" {
+				var err error
+				if offset, err = ParseDuration(m[2]); err != nil {
+					return nil, fmt.Errorf("invalid %s %s: %v", bc.constraint, o.key.typ, bc.raw)
+				}
+				offset.Negative = m[1] == "-"
+			}
+			parsed.relative = &offset
+		} else {
+			v, err := o.parse(bc.raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %s: %v", bc.constraint, o.key.typ, bc.raw)
+			}
+			parsed.value = v
+		}
+		b.bounds = append(b.bounds, parsed)
+	}
+	return b, nil
+}
+
+// checkBounds checks whether v satisfies the minimum, maximum, exclusiveMinimum and
+// exclusiveMaximum constraints. Bounds parsed when the field was unmarshalled are used
+// if they still correspond to the constraints.
+func checkBounds(v interface{}, c Constraints, o ordering) error {
+	if !c.hasBounds() {
+		return nil
+	}
+	b := c.bounds
+	if b == nil || b.key != o.withConstraints(c) {
+		var err error
+		if b, err = parseBounds(c, o); err != nil {
+			return err
+		}
+	}
+	for _, bound := range b.bounds {
+		limit := bound.value
+		if bound.relative != nil {
+			limit = o.now(*bound.relative)
+		}
+		cmp, err := o.compare(v, limit)
+		if err != nil {
+			return err
+		}
+		op := ""
+		switch {
+		case bound.constraint == minimumConstraint && cmp < 0:
+			op = "<"
+		case bound.constraint == maximumConstraint && cmp > 0:
+			op = ">"
+		case bound.constraint == exclusiveMinimumConstraint && cmp <= 0:
+			op = "<="
+		case bound.constraint == exclusiveMaximumConstraint && cmp >= 0:
+			op = ">="
+		}
+		if op != "" {
+			return fmt.Errorf("constraint check error: %s:%v %s %s:%v", o.key.typ, v, op, bound.constraint, limit)
+		}
+	}
+	return nil
+}
+
+// ordering returns how values of the field type are ordered. It returns false if the
+// type is not ordered or the field bounds do not follow its order (e.g. categories).
+func (f *Field) ordering() (ordering, bool) {
+	if f.CategoriesOrdered && len(f.Categories) > 0 {
+		return ordering{}, false
+	}
+	switch f.Type {
+	case IntegerType:
+		return integerOrdering, true
+	case NumberType:
+		return numberOrdering, true
+	case DateType:
+		return dateOrdering(f.Format, f.temporalOptions()), true
+	case DateTimeType:
+		return dateTimeOrdering(f.Format, f.temporalOptions()), true
+	case TimeType:
+		return timeOrdering(f.Format, f.temporalOptions()), true
+	case YearType:
+		return yearOrdering, true
+	case YearMonthType:
+		return yearMonthOrdering, true
+	case DurationType:
+		return durationOrdering, true
+	}
+	return ordering{}, false
+}
+
+func compareTimes(a, b interface{}) (int, error) {
+	ta, tb := a.(time.Time), b.(time.Time)
+	switch {
+	case ta.Before(tb):
+		return -1, nil
+	case ta.After(tb):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+var (
+	integerOrdering = ordering{
+		key: boundsKey{typ: IntegerType},
+		parse: func(s string) (interface{}, error) {
+			return strconv.ParseInt(s, 10, 64)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			ia, ib := a.(int64), b.(int64)
+			switch {
+			case ia < ib:
+				return -1, nil
+			case ia > ib:
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+	numberOrdering = ordering{
+		key: boundsKey{typ: NumberType},
+		parse: func(s string) (interface{}, error) {
+			return strconv.ParseFloat(s, 64)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			fa, fb := a.(float64), b.(float64)
+			switch {
+			case fa < fb:
+				return -1, nil
+			case fa > fb:
+				return 1, nil
+			}
+			return 0, nil
+		},
+	}
+	yearOrdering = ordering{
+		key: boundsKey{typ: YearType},
+		parse: func(s string) (interface{}, error) {
+			return ParseYear(s)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			return a.(Year).Compare(b.(Year)), nil
+		},
+	}
+	yearMonthOrdering = ordering{
+		key: boundsKey{typ: YearMonthType},
+		parse: func(s string) (interface{}, error) {
+			return ParseYearMonth(s)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			return a.(YearMonth).Compare(b.(YearMonth)), nil
+		},
+	}
+	durationOrdering = ordering{
+		key: boundsKey{typ: DurationType},
+		parse: func(s string) (interface{}, error) {
+			return ParseDuration(s)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			return a.(Duration).Compare(b.(Duration))
+		},
+	}
+)
+
+func temporalBoundsKey(typ, format string, opts temporalOptions) boundsKey {
+	return boundsKey{typ: typ, format: format, order: opts.order, loc: opts.loc}
+}
+
+// dateOrdering orders dates. Relative bounds are applied to the current date in the
+// field location.
+func dateOrdering(format string, opts temporalOptions) ordering {
+	return ordering{
+		key: temporalBoundsKey(DateType, format, opts),
+		parse: func(s string) (interface{}, error) {
+			return decodeDateWithoutChecks(format, opts, s)
+		},
+		compare: compareTimes,
+		now: func(offset Duration) interface{} {
+			y, m, d := opts.clock().In(opts.location()).Date()
+			return offset.AddTo(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+		},
+	}
+}
+
+func dateTimeOrdering(format string, opts temporalOptions) ordering {
+	return ordering{
+		key: temporalBoundsKey(DateTimeType, format, opts),
+		parse: func(s string) (interface{}, error) {
+			return decodeDateTimeWithoutChecks(format, opts, s)
+		},
+		compare: compareTimes,
+		now: func(offset Duration) interface{} {
+			return offset.AddTo(opts.clock().In(time.UTC))
+		},
+	}
+}
+
+func timeOrdering(format string, opts temporalOptions) ordering {
+	return ordering{
+		key: temporalBoundsKey(TimeType, format, opts),
+		parse: func(s string) (interface{}, error) {
+			return decodeTimeWithoutCheckConstraints(format, opts, s)
+		},
+		compare: func(a, b interface{}) (int, error) {
+			return a.(TimeOfDay).Compare(b.(TimeOfDay)), nil
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestExclusiveBounds(t *testing.T) {
+	data := []struct {
+		desc  string
+		field Field
+		value string
+		valid bool
+	}{
+		{"IntegerAboveExclusiveMinimum", Field{Type: IntegerType, Constraints: Constraints{ExclusiveMinimum: "1"}}, "2", true},
+		{"IntegerEqualExclusiveMinimum", Field{Type: IntegerType, Constraints: Constraints{ExclusiveMinimum: "1"}}, "1", false},
+		{"IntegerEqualExclusiveMaximum", Field{Type: IntegerType, Constraints: Constraints{ExclusiveMaximum: "10"}}, "10", false},
+		{"IntegerEqualMaximum", Field{Type: IntegerType, Constraints: Constraints{Maximum: "10", ExclusiveMinimum: "0"}}, "10", true},
+		{"NumberBelowExclusiveMaximum", Field{Type: NumberType, Constraints: Constraints{ExclusiveMaximum: "1"}}, "0.99", true},
+		{"NumberEqualExclusiveMaximum", Field{Type: NumberType, Constraints: Constraints{ExclusiveMaximum: "1"}}, "1.0", false},
+		{"DateEqualExclusiveMinimum", Field{Type: DateType, Constraints: Constraints{ExclusiveMinimum: "2017-01-01"}}, "2017-01-01", false},
+		{"DateAfterExclusiveMinimum", Field{Type: DateType, Constraints: Constraints{ExclusiveMinimum: "2017-01-01"}}, "2017-01-02", true},
+		{"DateTimeEqualExclusiveMaximum", Field{Type: DateTimeType, Constraints: Constraints{ExclusiveMaximum: "2017-01-01T10:00:00Z"}}, "2017-01-01T12:00:00+02:00", false},
+		{"TimeEqualExclusiveMaximum", Field{Type: TimeType, Constraints: Constraints{ExclusiveMaximum: "12:00:00"}}, "12:00:00", false},
+		{"YearEqualExclusiveMinimum", Field{Type: YearType, Constraints: Constraints{ExclusiveMinimum: "2000"}}, "2000", false},
+		{"YearMonthBelowExclusiveMaximum", Field{Type: YearMonthType, Constraints: Constraints{ExclusiveMaximum: "2000-02"}}, "2000-01", true},
+		{"DurationEqualExclusiveMaximum", Field{Type: DurationType, Constraints: Constraints{ExclusiveMaximum: "PT1H"}}, "PT60M", false},
+		{"InvalidExclusiveMinimum", Field{Type: IntegerType, Constraints: Constraints{ExclusiveMinimum: "foo"}}, "1", false},
+		{"OrderedCategories", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{ExclusiveMinimum: "agree"}}, "neutral", true},
+		{"OrderedCategoriesEqualExclusiveMinimum", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{ExclusiveMinimum: "agree"}}, "agree", false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := d.field.Decode(d.value)
+			is.Equal(err == nil, d.valid)
+		})
+	}
+}
+
+func TestRelativeBounds(t *testing.T) {
+	clock := func() time.Time { return time.Date(2017, time.June, 15, 22, 30, 0, 0, time.UTC) }
+	data := []struct {
+		desc  string
+		field Field
+		value string
+		valid bool
+	}{
+		{"DateNotInTheFuture", Field{Type: DateType, Clock: clock, Constraints: Constraints{Maximum: "now"}}, "2017-06-15", true},
+		{"DateInTheFuture", Field{Type: DateType, Clock: clock, Constraints: Constraints{Maximum: "now"}}, "2017-06-16", false},
+		{"DateWithinLastYear", Field{Type: DateType, Clock: clock, Constraints: Constraints{Minimum: "now-P1Y"}}, "2016-06-15", true},
+		{"DateBeforeLastYear", Field{Type: DateType, Clock: clock, Constraints: Constraints{Minimum: "now-P1Y"}}, "2016-06-14", false},
+		{"DateExclusive", Field{Type: DateType, Clock: clock, Constraints: Constraints{ExclusiveMaximum: "now"}}, "2017-06-15", false},
+		{"DateInFieldLocation", Field{Type: DateType, Clock: clock, Location: time.FixedZone("UTC+2", 2*3600), Constraints: Constraints{Maximum: "now"}}, "2017-06-16", true},
+		{"DateTimeBeforeNow", Field{Type: DateTimeType, Clock: clock, Constraints: Constraints{Maximum: "now"}}, "2017-06-15T22:29:59Z", true},
+		{"DateTimeAfterNow", Field{Type: DateTimeType, Clock: clock, Constraints: Constraints{Maximum: "now"}}, "2017-06-15T22:30:01Z", false},
+		{"DateTimeWithinHours", Field{Type: DateTimeType, Clock: clock, Constraints: Constraints{Maximum: "now+PT12H"}}, "2017-06-16T10:30:00Z", true},
+		{"DateTimeAfterHours", Field{Type: DateTimeType, Clock: clock, Constraints: Constraints{Maximum: "now+PT12H"}}, "2017-06-16T10:30:01Z", false},
+		{"InvalidOffset", Field{Type: DateType, Clock: clock, Constraints: Constraints{Maximum: "now-1Y"}}, "2017-06-15", false},
+		{"NotSupportedByIntegers", Field{Type: IntegerType, Constraints: Constraints{Maximum: "now"}}, "1", false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := d.field.Decode(d.value)
+			is.Equal(err == nil, d.valid)
+		})
+	}
+	t.Run("SchemaClock", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Read(strings.NewReader(`{"fields":[{"name":"Day","type":"date","constraints":{"maximum":"now"}}]}`))
+		is.NoErr(err)
+		s.Clock = clock
+		var row struct{ Day time.Time }
+		is.NoErr(s.Decode([]string{"2017-06-15"}, &row))
+		is.True(s.Decode([]string{"2017-06-16"}, &row) != nil)
+	})
+}
+
+func TestBounds_ParsedOnce(t *testing.T) {
+	is := is.New(t)
+	var f Field
+	is.NoErr(json.Unmarshal([]byte(`{"name":"n","type":"integer","constraints":{"minimum":"1","exclusiveMaximum":"10"}}`), &f))
+	is.True(f.Constraints.bounds != nil)
+	is.Equal(len(f.Constraints.bounds.bounds), 2)
+	_, err := f.Decode("9")
+	is.NoErr(err)
+	_, err = f.Decode("10")
+	is.True(err != nil)
+
+	// Parsed bounds are not used once constraints change.
+	f.Constraints.ExclusiveMaximum = "20"
+	_, err = f.Decode("10")
+	is.NoErr(err)
+
+	// Invalid bounds are reported when decoding.
+	is.NoErr(json.Unmarshal([]byte(`{"name":"n","type":"integer","constraints":{"minimum":"foo"}}`), &f))
+	_, err = f.Decode("1")
+	is.True(err != nil)
+}
@@ -0,0 +1,234 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Category is one of the values allowed by a categorical field. Value is the
+// physical representation of the category (e.g. "1") and Label, if not empty, its
+// human readable name (e.g. "agree").
+// More at: https://datapackage.org/standard/table-schema/#categories
+type Category struct {
+	Value string
+	Label string
+}
+
+// Categories lists the values allowed by string and integer fields. In JSON, it is
+// either a list of values or a list of objects with value and label.
+type Categories []Category
+
+// Index returns the position of the category which has the passed-in value or
+// label, or -1 if there is no such category.
+func (c Categories) Index(valueOrLabel string) int {
+	for i, cat := range c {
+		if cat.Value == valueOrLabel {
+			return i
+		}
+	}
+	for i, cat := range c {
+		if cat.Label != "" && cat.Label == valueOrLabel {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c Categories) hasLabels() bool {
+	for _, cat := range c {
+		if cat.Label != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON implements json.Marshaler. Categories without labels are written as
+// a list of values.
+func (c Categories) MarshalJSON() ([]byte, error) {
+	list := make([]interface{}, len(c))
+	labels := c.hasLabels()
+	for i, cat := range c {
+		var v interface{} = cat.Value
+		if n, err := strconv.ParseInt(cat.Value, 10, 64); err == nil && strconv.FormatInt(n, 10) == cat.Value {
+			v = n
+		}
+		if labels {
+			list[i] = map[string]interface{}{"value": v, "label": cat.Label}
+		} else {
+			list[i] = v
+		}
+	}
+	return json.Marshal(list)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Categories) UnmarshalJSON(data []byte) error {
+	var list []interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&list); err != nil {
+		return fmt.Errorf("invalid categories: %v", err)
+	}
+	cats := make(Categories, len(list))
+	for i, e := range list {
+		if obj, ok := e.(map[string]interface{}); ok {
+			v, err := categoryValue(obj["value"])
+			if err != nil {
+				return err
+			}
+			label, ok := obj["label"].(string)
+			if !ok {
+				return fmt.Errorf("invalid categories: label of %s must be a string", v)
+			}
+			cats[i] = Category{Value: v, Label: label}
+			continue
+		}
+		v, err := categoryValue(e)
+		if err != nil {
+			return err
+		}
+		cats[i] = Category{Value: v}
+	}
+	// Values are matched before labels, a label being the value of another category
+	// could not be decoded.
+	for i, cat := range cats {
+		if j := cats.Index(cat.Label); cat.Label != "" && j != i {
+			return fmt.Errorf("invalid categories: label %s of category %s is the value of category %s", cat.Label, cat.Value, cats[j].Value)
+		}
+	}
+	*c = cats
+	return nil
+}
+
+func categoryValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case json.Number:
+		if _, err := value.Int64(); err != nil {
+			return "", fmt.Errorf("invalid categories: %s is not an integer", value)
+		}
+		return value.String(), nil
+	}
+	return "", fmt.Errorf("invalid categories: value %v must be a string or an integer", v)
+}
+
+// decodeCategorical decodes a string or integer field which has categories. The
+// value, which can be written as the category value or label, must be one of the
+// categories. If the categories are ordered, bound constraints (e.g. minimum) are
+// category values (or labels) and are compared using the categories order.
+func decodeCategorical(f *Field, value string) (interface{}, error) {
+	i := categoryIndex(f, value)
+	if i < 0 {
+		return nil, fmt.Errorf("constraint check error: %s is not one of the field categories", value)
+	}
+	cat := f.Categories[i]
+	c := f.Constraints
+	if f.CategoriesOrdered {
+		for _, bound := range []struct {
+			name, value string
+			cmp         func(int) bool
+		}{
+			{minimumConstraint, c.Minimum, func(b int) bool { return i < b }},
+			{maximumConstraint, c.Maximum, func(b int) bool { return i > b }},
+			{exclusiveMinimumConstraint, c.ExclusiveMinimum, func(b int) bool { return i <= b }},
+			{exclusiveMaximumConstraint, c.ExclusiveMaximum, func(b int) bool { return i >= b }},
+		} {
+			if bound.value == "" {
+				continue
+			}
+			b := categoryIndex(f, bound.value)
+			if b < 0 {
+				return nil, fmt.Errorf("invalid %s category: %s", bound.name, bound.value)
+			}
+			if bound.cmp(b) {
+				return nil, fmt.Errorf("constraint check error: category %s out of %s:%s", cat.Value, bound.name, bound.value)
+			}
+		}
+		c.Minimum, c.Maximum, c.ExclusiveMinimum, c.ExclusiveMaximum = "", "", "", ""
+	}
+	if f.CategoriesAsLabels && cat.Label != "" {
+		return cat.Label, nil
+	}
+	if f.Type == IntegerType {
+		return castInt(f.BareNumber, cat.Value, c)
+	}
+	return decodeString(f.Format, f.UUIDVersion, cat.Value, c)
+}
+
+// categoryIndex returns the index of the category with the passed-in value or label.
+// Values of integer fields are compared as integers, so "01" is the category "1".
+func categoryIndex(f *Field, valueOrLabel string) int {
+	if i := f.Categories.Index(valueOrLabel); i >= 0 || f.Type != IntegerType {
+		return i
+	}
+	v, err := castInt(f.BareNumber, valueOrLabel, noConstraints)
+	if err != nil {
+		return -1
+	}
+	for i, cat := range f.Categories {
+		if c, err := strconv.ParseInt(cat.Value, 10, 64); err == nil && c == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// encodeCategoryLabel returns the value of the category with the passed-in value or
+// label, which are matched as when decoding.
+func encodeCategoryLabel(f *Field, in interface{}) (string, bool) {
+	valueOrLabel, ok := in.(string)
+	if !ok {
+		return "", false
+	}
+	i := categoryIndex(f, valueOrLabel)
+	if i < 0 {
+		return "", false
+	}
+	return f.Categories[i].Value, true
+}
+
+// Maximum number of categories proposed by InferCategories, if not set.
+const defaultMaxInferredCategories = 10
+
+// inferCategories sets the categories of string and integer fields which have at most
+// max distinct values. Values must repeat, otherwise all columns of small samples would
+// be categorical.
+func inferCategories(s *Schema, table [][]string, max int) {
+	for index := range s.Fields {
+		f := &s.Fields[index]
+		if f.Type != StringType && f.Type != IntegerType {
+			continue
+		}
+		distinct := make(map[string]struct{})
+		for _, row := range table {
+			if row[index] != "" {
+				distinct[row[index]] = struct{}{}
+			}
+		}
+		if len(distinct) == 0 || len(distinct) > max || len(distinct) == len(table) {
+			continue
+		}
+		values := make([]string, 0, len(distinct))
+		for v := range distinct {
+			values = append(values, v)
+		}
+		if f.Type == IntegerType {
+			sort.Slice(values, func(i, j int) bool {
+				a, _ := strconv.ParseInt(values[i], 10, 64)
+				b, _ := strconv.ParseInt(values[j], 10, 64)
+				return a < b
+			})
+		} else {
+			sort.Strings(values)
+		}
+		f.Categories = make(Categories, len(values))
+		for i, v := range values {
+			f.Categories[i] = Category{Value: v}
+		}
+	}
+}
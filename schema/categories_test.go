@@ -0,0 +1,162 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+
+	"github.com/frictionlessdata/tableschema-go/table"
+)
+
+var surveyCategories = Categories{{"1", "agree"}, {"2", "neutral"}, {"3", "disagree"}}
+
+func TestCategories_JSON(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"color","type":"string","categories":["red","green"]}`), &f))
+		is.Equal(f.Categories, Categories{{Value: "red"}, {Value: "green"}})
+		b, err := json.Marshal(f.Categories)
+		is.NoErr(err)
+		is.Equal(string(b), `["red","green"]`)
+	})
+	t.Run("Labels", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.NoErr(json.Unmarshal([]byte(`{"name":"answer","type":"integer","categoriesOrdered":true,"categories":[{"value":1,"label":"agree"},{"value":2,"label":"neutral"},{"value":3,"label":"disagree"}]}`), &f))
+		is.Equal(f.Categories, surveyCategories)
+		is.True(f.CategoriesOrdered)
+		b, err := json.Marshal(f.Categories)
+		is.NoErr(err)
+		is.Equal(string(b), `[{"label":"agree","value":1},{"label":"neutral","value":2},{"label":"disagree","value":3}]`)
+	})
+	t.Run("LabelIsOwnValue", func(t *testing.T) {
+		is := is.New(t)
+		var c Categories
+		is.NoErr(json.Unmarshal([]byte(`[{"value":"a","label":"a"}]`), &c))
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc string
+			json string
+		}{
+			{"NotList", `{"name":"n","categories":"red"}`},
+			{"Float", `{"name":"n","categories":[1.5]}`},
+			{"Boolean", `{"name":"n","categories":[true]}`},
+			{"LabelNotString", `{"name":"n","categories":[{"value":1,"label":2}]}`},
+			{"LabelIsOtherValue", `{"name":"n","categories":[{"value":"a","label":"b"},{"value":"b","label":"c"}]}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				var f Field
+				is.True(json.Unmarshal([]byte(d.json), &f) != nil)
+			})
+		}
+	})
+}
+
+func TestDecodeCategorical(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+			want  interface{}
+		}{
+			{"String", Field{Type: StringType, Categories: Categories{{Value: "red"}, {Value: "green"}}}, "green", "green"},
+			{"Integer", Field{Type: IntegerType, Categories: surveyCategories}, "2", int64(2)},
+			{"IntegerLeadingZero", Field{Type: IntegerType, Categories: surveyCategories}, "02", int64(2)},
+			{"LabelToValue", Field{Type: IntegerType, Categories: surveyCategories}, "neutral", int64(2)},
+			{"ValueToLabel", Field{Type: IntegerType, Categories: surveyCategories, CategoriesAsLabels: true}, "2", "neutral"},
+			{"ValueBeforeLabel", Field{Type: StringType, Categories: Categories{{"a", "b"}, {"b", "c"}}}, "b", "b"},
+			{"OrderedWithinBounds", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{Minimum: "agree", Maximum: "2"}}, "2", int64(2)},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := d.field.Decode(d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+		}{
+			{"NotCategory", Field{Type: StringType, Categories: Categories{{Value: "red"}}}, "blue"},
+			{"IntegerNotCategory", Field{Type: IntegerType, Categories: surveyCategories}, "4"},
+			{"BelowMinimum", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{Minimum: "neutral"}}, "1"},
+			{"AboveMaximum", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{Maximum: "neutral"}}, "disagree"},
+			{"InvalidBound", Field{Type: IntegerType, Categories: surveyCategories, CategoriesOrdered: true, Constraints: Constraints{Maximum: "foo"}}, "1"},
+			{"UnorderedIntegerBounds", Field{Type: IntegerType, Categories: surveyCategories, Constraints: Constraints{Maximum: "2"}}, "3"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := d.field.Decode(d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestEncodeCategorical(t *testing.T) {
+	data := []struct {
+		desc  string
+		field Field
+		value interface{}
+		want  string
+	}{
+		{"Label", Field{Type: IntegerType, Categories: surveyCategories}, "disagree", "3"},
+		{"Value", Field{Type: IntegerType, Categories: surveyCategories}, 3, "3"},
+		{"String", Field{Type: StringType, Categories: Categories{{"r", "red"}}}, "red", "r"},
+		{"StringValue", Field{Type: StringType, Categories: Categories{{"r", "red"}}}, "r", "r"},
+		// Values are matched before labels, as when decoding.
+		{"ValueBeforeLabel", Field{Type: StringType, Categories: Categories{{"a", "b"}, {"b", "c"}}}, "b", "b"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := d.field.Encode(d.value)
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+}
+
+func TestInferCategories(t *testing.T) {
+	tab := table.FromSlices(
+		[]string{"Answer", "Color", "Name"},
+		[][]string{
+			[]string{"3", "red", "Foo"},
+			[]string{"20", "green", "Bar"},
+			[]string{"10", "red", "Bez"},
+			[]string{"20", "", "Boo"},
+		})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab, InferCategories(0))
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Categories, Categories{{Value: "3"}, {Value: "10"}, {Value: "20"}})
+		is.Equal(s.Fields[1].Categories, Categories{{Value: "green"}, {Value: "red"}})
+		is.Equal(len(s.Fields[2].Categories), 0) // all values are distinct
+	})
+	t.Run("InferImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		s, err := InferImplicitCasting(tab, InferCategories(2))
+		is.NoErr(err)
+		is.Equal(len(s.Fields[0].Categories), 0) // too many categories
+		is.Equal(s.Fields[1].Categories, Categories{{Value: "green"}, {Value: "red"}})
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Infer(tab)
+		is.NoErr(err)
+		is.Equal(len(s.Fields[1].Categories), 0)
+	})
+}
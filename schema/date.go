@@ -2,27 +2,18 @@ package schema
 
 import "time"
 
-func decodeDate(format, value string, c Constraints) (time.Time, error) {
-	y, err := decodeDateWithoutChecks(format, value)
+func decodeDate(format string, opts temporalOptions, value string, c Constraints) (time.Time, error) {
+	y, err := decodeDateWithoutChecks(format, opts, value)
 	if err != nil {
 		return y, err
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = decodeDateWithoutChecks(format, c.Maximum)
-		if err != nil {
-			return max, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = decodeDateWithoutChecks(format, c.Minimum)
-		if err != nil {
-			return min, err
-		}
-	}
-	return checkConstraints(y, max, min, DateType)
+	return y, checkBounds(y, c, dateOrdering(format, opts))
 }
 
-func decodeDateWithoutChecks(format, value string) (time.Time, error) {
-	return decodeDefaultOrCustomTime("2006-01-02", format, value)
+var defaultDateLayouts = []string{"2006-01-02"}
+
+func decodeDateWithoutChecks(format string, opts temporalOptions, value string) (time.Time, error) {
+	// Dates have no time zone information.
+	opts.loc, opts.keepOffset = nil, false
+	return decodeDefaultOrCustomTime(DateType, defaultDateLayouts, format, opts, value)
 }
@@ -9,12 +9,12 @@ import (
 func TestDecodeDate(t *testing.T) {
 	t.Run("ValidMaximum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeDate("2006-01-02", "2006-01-02", Constraints{Maximum: "2007-01-02"})
+		_, err := decodeDate("%Y-%m-%d", noTemporalOptions, "2006-01-02", Constraints{Maximum: "2007-01-02"})
 		is.NoErr(err)
 	})
 	t.Run("ValidMinimum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeDate("2006-01-02", "2007-01-02", Constraints{Minimum: "2006-01-02"})
+		_, err := decodeDate("%Y-%m-%d", noTemporalOptions, "2007-01-02", Constraints{Minimum: "2006-01-02"})
 		is.NoErr(err)
 	})
 	t.Run("Error", func(t *testing.T) {
@@ -32,7 +32,7 @@ func TestDecodeDate(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := decodeDate("2006-01-02", d.date, d.constraints)
+				_, err := decodeDate("%Y-%m-%d", noTemporalOptions, d.date, d.constraints)
 				is.True(err != nil)
 			})
 		}
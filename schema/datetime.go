@@ -1,139 +1,74 @@
 package schema
 
-import (
-	"fmt"
-	"strings"
-	"time"
-)
+import "time"
 
-// Go has a different date formatting style. Converting to the one
-// used in https://specs.frictionlessdata.io/table-schema/#date
-// https://docs.python.org/2/library/datetime.html#strftime-strptime-behavior
-var strftimeToGoConversionTable = map[string]string{
-	"%d":  "02",
-	"%-d": "2",
-	"%B":  "January",
-	"%b":  "Jan",
-	"%h":  "Jan",
-	"%m":  "01",
-	"%_m": " 1",
-	"%-m": "1",
-	"%Y":  "2006",
-	"%y":  "06",
-	"%H":  "15",
-	"%I":  "03",
-	"%M":  "04",
-	"%S":  "05",
-	"%f":  "999999",
-	"%z":  "Z0700",
-	"%:z": "Z07:00",
-	"%Z":  "MST",
-	"%p":  "PM",
-}
-
-func decodeYearMonth(value string, c Constraints) (time.Time, error) {
-	y, err := decodeYearMonthWithoutChecks(value)
+func decodeDateTime(format string, opts temporalOptions, value string, c Constraints) (time.Time, error) {
+	dt, err := decodeDateTimeWithoutChecks(format, opts, value)
 	if err != nil {
-		return y, err
-	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = decodeYearMonthWithoutChecks(c.Maximum)
-		if err != nil {
-			return y, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = decodeYearMonthWithoutChecks(c.Minimum)
-		if err != nil {
-			return y, err
-		}
+		return dt, err
 	}
-	return checkConstraints(y, max, min, YearMonthType)
+	return dt, checkBounds(dt, c, dateTimeOrdering(format, opts))
 }
 
-func decodeYearMonthWithoutChecks(value string) (time.Time, error) {
-	return time.Parse("2006-01", value)
-}
+// Layouts accepted by the datetime default format. Values without UTC offset
+// are interpreted in the field location.
+var defaultDateTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05"}
 
-func decodeYearWithoutChecks(value string) (time.Time, error) {
-	return time.Parse("2006", value)
+func decodeDateTimeWithoutChecks(format string, opts temporalOptions, value string) (time.Time, error) {
+	return decodeDefaultOrCustomTime(DateTimeType, defaultDateTimeLayouts, format, opts, value)
 }
 
-func decodeYear(value string, c Constraints) (time.Time, error) {
-	y, err := decodeYearWithoutChecks(value)
-	if err != nil {
-		return y, err
-	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = decodeYearWithoutChecks(c.Maximum)
-		if err != nil {
-			return y, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = decodeYearWithoutChecks(c.Minimum)
-		if err != nil {
-			return y, err
-		}
-	}
-	return checkConstraints(y, max, min, YearType)
+// temporalOptions holds the field properties used to decode date, datetime and
+// time values.
+type temporalOptions struct {
+	// Order of numeric dates used by the "any" format.
+	order DateOrder
+	// Location used to interpret values without UTC offset. Nil means UTC.
+	loc *time.Location
+	// Whether the decoded value keeps its original offset instead of being converted to UTC.
+	keepOffset bool
+	// Returns the current time. Nil means time.Now.
+	now func() time.Time
 }
 
-func decodeDateTime(value string, c Constraints) (time.Time, error) {
-	dt, err := decodeDateTimeWithoutChecks(value)
-	if err != nil {
-		return dt, err
-	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = decodeDateTimeWithoutChecks(c.Maximum)
-		if err != nil {
-			return dt, err
-		}
-	}
-	if c.Minimum != "" {
-		min, err = decodeDateTimeWithoutChecks(c.Minimum)
-		if err != nil {
-			return dt, err
-		}
+func (o temporalOptions) clock() time.Time {
+	if o.now == nil {
+		return time.Now()
 	}
-	return checkConstraints(dt, max, min, DateTimeType)
-}
-
-func decodeDateTimeWithoutChecks(value string) (time.Time, error) {
-	return time.Parse(time.RFC3339, value)
+	return o.now()
 }
 
-func checkConstraints(v, max, min time.Time, t string) (time.Time, error) {
-	if !max.IsZero() && v.After(max) {
-		return v, fmt.Errorf("constraint check error: %s:%v > maximum:%v", t, v, max)
+func (o temporalOptions) location() *time.Location {
+	if o.loc == nil {
+		return time.UTC
 	}
-	if !min.IsZero() && v.Before(min) {
-		return v, fmt.Errorf("constraint check error: %s:%v < minimum:%v", t, v, min)
-	}
-	return v, nil
+	return o.loc
 }
 
-func decodeDefaultOrCustomTime(defaultFormat, format, value string) (time.Time, error) {
+// decodeDefaultOrCustomTime decodes value according to the field format. The default
+// format accepts any of the passed-in Go layouts. The field type (DateType, TimeType or
+// DateTimeType) is used by the "any" format.
+func decodeDefaultOrCustomTime(fieldType string, defaultLayouts []string, format string, opts temporalOptions, value string) (time.Time, error) {
+	loc := opts.location()
+	var t time.Time
+	var err error
 	switch format {
 	case "", defaultFieldFormat:
-		t, err := time.Parse(defaultFormat, value)
-		if err != nil {
-			return t, err
+		for _, l := range defaultLayouts {
+			if t, err = time.ParseInLocation(l, value, loc); err == nil {
+				break
+			}
 		}
-		return t.In(time.UTC), nil
 	case AnyDateFormat:
-		return time.Unix(0, 0), fmt.Errorf("any date format not yet supported. Please file an issue at github.com/frictionlessdata/tableschema-go")
-	}
-	goFormat := format
-	for f, s := range strftimeToGoConversionTable {
-		goFormat = strings.Replace(goFormat, f, s, -1)
+		t, err = decodeAnyTime(fieldType, opts.order, loc, value)
+	default:
+		t, err = strptime(format, value, loc)
 	}
-	t, err := time.Parse(goFormat, value)
 	if err != nil {
 		return t, err
 	}
+	if opts.keepOffset {
+		return t, nil
+	}
 	return t.In(time.UTC), nil
 }
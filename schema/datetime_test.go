@@ -2,6 +2,7 @@ package schema
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -9,12 +10,12 @@ import (
 func TestDecodeDatetime(t *testing.T) {
 	t.Run("ValidMaximum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeDateTime("2013-01-24T22:01:00+07:00", Constraints{Maximum: "2014-01-24T22:01:00Z"})
+		_, err := decodeDateTime(defaultFieldFormat, noTemporalOptions, "2013-01-24T22:01:00+07:00", Constraints{Maximum: "2014-01-24T22:01:00Z"})
 		is.NoErr(err)
 	})
 	t.Run("ValidMinimum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeDateTime("2013-01-24T22:01:00Z", Constraints{Minimum: "2012-01-24T22:01:00Z"})
+		_, err := decodeDateTime(defaultFieldFormat, noTemporalOptions, "2013-01-24T22:01:00Z", Constraints{Minimum: "2012-01-24T22:01:00Z"})
 		is.NoErr(err)
 	})
 	t.Run("Error", func(t *testing.T) {
@@ -52,75 +53,69 @@ func TestDecodeDatetime(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := decodeDateTime(d.datetime, d.constraints)
+				_, err := decodeDateTime(defaultFieldFormat, noTemporalOptions, d.datetime, d.constraints)
 				is.True(err != nil)
 			})
 		}
 	})
 }
 
-func TestDecodeYear(t *testing.T) {
-	t.Run("ValidMaximum", func(t *testing.T) {
-		is := is.New(t)
-		_, err := decodeYear("2006", Constraints{Maximum: "2007"})
-		is.NoErr(err)
-	})
-	t.Run("ValidMinimum", func(t *testing.T) {
-		is := is.New(t)
-		_, err := decodeYear("2007", Constraints{Minimum: "2006"})
-		is.NoErr(err)
-	})
-	t.Run("Error", func(t *testing.T) {
-		data := []struct {
-			desc        string
-			year        string
-			constraints Constraints
-		}{
-			{"InvalidYear", "foo", Constraints{}},
-			{"YearBiggerThanMaximum", "2006", Constraints{Maximum: "2005"}},
-			{"InvalidMaximum", "2005", Constraints{Maximum: "boo"}},
-			{"YearSmallerThanMinimum", "2005", Constraints{Minimum: "2006"}},
-			{"InvalidMinimum", "2005", Constraints{Minimum: "boo"}},
-		}
-		for _, d := range data {
-			t.Run(d.desc, func(t *testing.T) {
-				is := is.New(t)
-				_, err := decodeYear(d.year, d.constraints)
-				is.True(err != nil)
-			})
-		}
-	})
-}
-
-func TestDecodeYearMonth(t *testing.T) {
-	t.Run("ValidMaximum", func(t *testing.T) {
+func TestDecodeDatetime_TimeZones(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*3600)
+	data := []struct {
+		desc      string
+		format    string
+		opts      temporalOptions
+		value     string
+		want      time.Time
+		wantZone  int
+		wantLocal bool
+	}{
+		{"OffsetToUTC", defaultFieldFormat, temporalOptions{}, "2017-12-31T13:45:00+01:00", time.Date(2017, 12, 31, 12, 45, 0, 0, time.UTC), 0, false},
+		{"NaiveUTC", defaultFieldFormat, temporalOptions{}, "2017-12-31T13:45:00", time.Date(2017, 12, 31, 13, 45, 0, 0, time.UTC), 0, false},
+		{"NaiveLocation", defaultFieldFormat, temporalOptions{loc: saoPaulo}, "2017-12-31T13:45:00", time.Date(2017, 12, 31, 16, 45, 0, 0, time.UTC), 0, false},
+		{"NaiveLocationKeepOffset", defaultFieldFormat, temporalOptions{loc: saoPaulo, keepOffset: true}, "2017-12-31T13:45:00", time.Date(2017, 12, 31, 16, 45, 0, 0, time.UTC), -3 * 3600, true},
+		{"KeepOffset", defaultFieldFormat, temporalOptions{loc: saoPaulo, keepOffset: true}, "2017-12-31T13:45:00+01:00", time.Date(2017, 12, 31, 12, 45, 0, 0, time.UTC), 3600, false},
+		{"FractionalSeconds", defaultFieldFormat, temporalOptions{}, "2017-12-31T13:45:00.123456Z", time.Date(2017, 12, 31, 13, 45, 0, 123456000, time.UTC), 0, false},
+		{"NaiveFractionalSeconds", defaultFieldFormat, temporalOptions{}, "2017-12-31T13:45:00.5", time.Date(2017, 12, 31, 13, 45, 0, 500000000, time.UTC), 0, false},
+		{"CustomFormatLocation", "%d/%m/%Y %H:%M", temporalOptions{loc: saoPaulo}, "31/12/2017 13:45", time.Date(2017, 12, 31, 16, 45, 0, 0, time.UTC), 0, false},
+		{"CustomFormatOffset", "%d/%m/%Y %H:%M%z", temporalOptions{loc: saoPaulo}, "31/12/2017 13:45+0000", time.Date(2017, 12, 31, 13, 45, 0, 0, time.UTC), 0, false},
+		{"AnyFormatLocation", AnyDateFormat, temporalOptions{loc: saoPaulo}, "31 Dec 2017 13:45", time.Date(2017, 12, 31, 16, 45, 0, 0, time.UTC), 0, false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := decodeDateTime(d.format, d.opts, d.value, Constraints{})
+			is.NoErr(err)
+			is.True(got.Equal(d.want)) // same instant
+			_, offset := got.Zone()
+			is.Equal(offset, d.wantZone)
+			if d.wantLocal {
+				is.Equal(got.Location(), d.opts.loc)
+			}
+		})
+	}
+	t.Run("ConstraintsInLocation", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeYearMonth("2006-02", Constraints{Maximum: "2006-03"})
-		is.NoErr(err)
+		_, err := decodeDateTime(defaultFieldFormat, temporalOptions{loc: saoPaulo}, "2017-12-31T13:45:00", Constraints{Maximum: "2017-12-31T16:00:00Z"})
+		is.True(err != nil) // 13:45 BRT is 16:45 UTC
 	})
-	t.Run("ValidMinimum", func(t *testing.T) {
+	t.Run("ZoneNameRoundTrip", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeYearMonth("2006-03", Constraints{Minimum: "2006-02"})
-		is.NoErr(err)
-	})
-	t.Run("Error", func(t *testing.T) {
-		data := []struct {
-			desc        string
-			year        string
-			constraints Constraints
-		}{
-			{"InvalidYear", "foo", Constraints{}},
-			{"YearBiggerThanMaximum", "2006-02", Constraints{Maximum: "2006-01"}},
-			{"InvalidMaximum", "2005-02", Constraints{Maximum: "boo"}},
-			{"YearSmallerThanMinimum", "2006-02", Constraints{Minimum: "2006-03"}},
-			{"InvalidMinimum", "2005-02", Constraints{Minimum: "boo"}},
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip(err)
 		}
-		for _, d := range data {
-			t.Run(d.desc, func(t *testing.T) {
-				is := is.New(t)
-				_, err := decodeYearMonth(d.year, d.constraints)
-				is.True(err != nil)
-			})
+		f := Field{Type: DateTimeType, Format: "%Y-%m-%d %H:%M:%S %Z", Location: ny}
+		for _, want := range []time.Time{
+			time.Date(2017, 12, 31, 13, 45, 0, 0, ny),
+			time.Date(2017, 7, 1, 13, 45, 0, 0, ny),
+		} {
+			s, err := f.Encode(want)
+			is.NoErr(err)
+			got, err := f.Decode(s)
+			is.NoErr(err)
+			is.True(got.(time.Time).Equal(want)) // round trip of s must not lose information
 		}
 	})
 }
@@ -1,7 +1,9 @@
 package schema
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -9,57 +11,235 @@ import (
 	"time"
 )
 
-var durationRegexp = regexp.MustCompile(
-	`P(?P<years>\d+Y)?(?P<months>\d+M)?(?P<days>\d+D)?T?(?P<hours>\d+H)?(?P<minutes>\d+M)?(?P<seconds>\d+\.?\d*S)?`)
+// Duration represents a "duration" cell, which is an ISO 8601 duration. Calendar
+// components (years, months, weeks and days) are kept apart from the clock components
+// because their length depends on the date they are applied to.
+// More at: https://specs.frictionlessdata.io/table-schema/#duration
+type Duration struct {
+	// Negative indicates whether the duration goes backwards in time (e.g. "-P1D").
+	Negative bool
+	Years    float64
+	Months   float64
+	Weeks    float64
+	Days     float64
+	Hours    float64
+	Minutes  float64
+	Seconds  float64
+}
 
-const (
-	hoursInYear  = time.Duration(24*360) * time.Hour
-	hoursInMonth = time.Duration(24*30) * time.Hour
-	hoursInDay   = time.Duration(24) * time.Hour
+var (
+	// Format with designators, e.g. P3Y6M4DT12H30M5S, PT0,5S or -P2W.
+	durationRegexp = regexp.MustCompile(
+		`^([-+])?P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	// Alternative format, e.g. P0003-06-04T12:30:05 (extended) or P00030604T123005 (basic).
+	durationAlternativeRegexp = regexp.MustCompile(
+		`^([-+])?P(\d{4})-?(\d{2})-?(\d{2})T(\d{2}):?(\d{2}):?(\d{2}(?:[.,]\d+)?)$`)
 )
 
-func castDuration(value string) (time.Duration, error) {
-	matches := durationRegexp.FindStringSubmatch(value)
-	if len(matches) == 0 {
-		return 0, fmt.Errorf("Invalid duration:\"%s\"", value)
+// ParseDuration parses an ISO 8601 duration.
+func ParseDuration(value string) (Duration, error) {
+	if m := durationRegexp.FindStringSubmatch(value); m != nil {
+		return parseDurationDesignators(value, m)
+	}
+	if m := durationAlternativeRegexp.FindStringSubmatch(value); m != nil {
+		return parseDurationAlternative(value, m)
+	}
+	return Duration{}, fmt.Errorf("invalid duration:\"%s\"", value)
+}
+
+func parseDurationDesignators(value string, m []string) (Duration, error) {
+	d := Duration{Negative: m[1] == "-"}
+	components := []struct {
+		str string
+		v   *float64
+	}{
+		{m[2], &d.Years}, {m[3], &d.Months}, {m[4], &d.Weeks}, {m[5], &d.Days},
+		{m[7], &d.Hours}, {m[8], &d.Minutes}, {m[9], &d.Seconds},
+	}
+	found, fraction := false, false
+	for _, c := range components {
+		if c.str == "" {
+			continue
+		}
+		// Only the smallest unit present can have a fraction.
+		if fraction {
+			return Duration{}, fmt.Errorf("invalid duration:\"%s\" only the smallest unit can have a fraction", value)
+		}
+		found = true
+		fraction = strings.ContainsAny(c.str, ".,")
+		*c.v, _ = strconv.ParseFloat(strings.Replace(c.str, ",", ".", 1), 64)
+	}
+	if !found {
+		return Duration{}, fmt.Errorf("invalid duration:\"%s\" at least one component is required", value)
+	}
+	if m[6] != "" && m[7] == "" && m[8] == "" && m[9] == "" {
+		return Duration{}, fmt.Errorf("invalid duration:\"%s\" time designator must be followed by a component", value)
+	}
+	return d, nil
+}
+
+func parseDurationAlternative(value string, m []string) (Duration, error) {
+	d := Duration{Negative: m[1] == "-"}
+	for i, v := range []*float64{&d.Years, &d.Months, &d.Days, &d.Hours, &d.Minutes, &d.Seconds} {
+		*v, _ = strconv.ParseFloat(strings.Replace(m[i+2], ",", ".", 1), 64)
 	}
-	years := parseIntDuration(matches[1], hoursInYear)
-	months := parseIntDuration(matches[2], hoursInMonth)
-	days := parseIntDuration(matches[3], hoursInDay)
-	hours := parseIntDuration(matches[4], time.Hour)
-	minutes := parseIntDuration(matches[5], time.Minute)
-	seconds := parseSeconds(matches[6])
-	return years + months + days + hours + minutes + seconds, nil
+	if d.Months > 12 || d.Days > 30 || d.Hours > 24 || d.Minutes > 59 || d.Seconds >= 60 {
+		return Duration{}, fmt.Errorf("invalid duration:\"%s\" component out of range", value)
+	}
+	return d, nil
 }
 
-func parseIntDuration(v string, multiplier time.Duration) time.Duration {
-	if len(v) == 0 {
-		return 0
+// String returns the canonical ISO 8601 representation of the duration. Zero components
+// are omitted and the zero duration is represented as "PT0S".
+func (d Duration) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+	var buf bytes.Buffer
+	if d.Negative {
+		buf.WriteByte('-')
+	}
+	buf.WriteByte('P')
+	writeDurationComponent(&buf, d.Years, 'Y')
+	writeDurationComponent(&buf, d.Months, 'M')
+	writeDurationComponent(&buf, d.Weeks, 'W')
+	writeDurationComponent(&buf, d.Days, 'D')
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		buf.WriteByte('T')
+		writeDurationComponent(&buf, d.Hours, 'H')
+		writeDurationComponent(&buf, d.Minutes, 'M')
+		writeDurationComponent(&buf, d.Seconds, 'S')
 	}
-	// Ignoring error here because only digits could come from the regular expression.
-	d, _ := strconv.Atoi(v[0 : len(v)-1])
-	return time.Duration(d) * multiplier
+	return buf.String()
 }
 
-func parseSeconds(v string) time.Duration {
-	if len(v) == 0 {
-		return 0
+func writeDurationComponent(buf *bytes.Buffer, v float64, designator byte) {
+	if v == 0 {
+		return
 	}
-	// Ignoring error here because only valid arbitrary precision floats could come from the regular expression.
-	d, _ := strconv.ParseFloat(v[0:len(v)-1], 64)
-	return time.Duration(d * 10e8)
+	buf.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	buf.WriteByte(designator)
+}
+
+// IsZero reports whether d represents the zero duration.
+func (d Duration) IsZero() bool {
+	return d.Years == 0 && d.Months == 0 && d.Weeks == 0 && d.Days == 0 && d.Hours == 0 && d.Minutes == 0 && d.Seconds == 0
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Average number of days in a month of the Gregorian calendar.
+const daysInAverageMonth = 365.2425 / 12
+
+// AddTo returns the time t+d. Calendar components are applied using the calendar
+// (i.e. P1M added to January 31st is March 3rd in non-leap years). Fractions of years
+// are applied as months and fractions of months as average Gregorian months.
+func (d Duration) AddTo(t time.Time) time.Time {
+	sign := 1
+	if d.Negative {
+		sign = -1
+	}
+	years, yearsFrac := math.Modf(d.Years)
+	months, monthsFrac := math.Modf(d.Months + yearsFrac*12)
+	days, daysFrac := math.Modf(d.Weeks*7 + d.Days + monthsFrac*daysInAverageMonth)
+	t = t.AddDate(sign*int(years), sign*int(months), sign*int(days))
+	clock := (daysFrac*24+d.Hours)*float64(time.Hour) + d.Minutes*float64(time.Minute) + d.Seconds*float64(time.Second)
+	return t.Add(time.Duration(sign) * roundDuration(clock))
+}
+
+// roundDuration rounds a non-negative number of nanoseconds to the nearest time.Duration,
+// avoiding float imprecision (e.g. 22.519s being 22518999999ns).
+func roundDuration(nanos float64) time.Duration {
+	return time.Duration(math.Floor(nanos + 0.5))
+}
+
+// Reference dates used to compare durations, as defined by XML Schema.
+// https://www.w3.org/TR/xmlschema11-2/#duration-order
+var durationReferenceTimes = []time.Time{
+	time.Date(1696, time.September, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1697, time.February, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, time.March, 1, 0, 0, 0, 0, time.UTC),
+	time.Date(1903, time.July, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// Compare returns -1, 0 or 1 if d is respectively shorter, equal or longer than o. Durations
+// with calendar components are compared by adding them to reference dates, if results differ
+// (e.g. P1M and P30D) the durations are not comparable and an error is returned.
+func (d Duration) Compare(o Duration) (int, error) {
+	result := 0
+	for i, ref := range durationReferenceTimes {
+		a, b := d.AddTo(ref), o.AddTo(ref)
+		c := 0
+		switch {
+		case a.Before(b):
+			c = -1
+		case a.After(b):
+			c = 1
+		}
+		if i > 0 && c != result {
+			return 0, fmt.Errorf("durations %s and %s are not comparable", d, o)
+		}
+		result = c
+	}
+	return result, nil
+}
+
+// ToDuration converts d to a time.Duration. Days and weeks are considered to have 24 and
+// 168 hours, respectively. An error is returned if d has years or months, as those do not
+// have a fixed length.
+func (d Duration) ToDuration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("duration %s can not be converted to time.Duration: years and months do not have a fixed length", d)
+	}
+	v := roundDuration(((d.Weeks*7+d.Days)*24+d.Hours)*float64(time.Hour) + d.Minutes*float64(time.Minute) + d.Seconds*float64(time.Second))
+	if d.Negative {
+		v = -v
+	}
+	return v, nil
+}
+
+// DurationOf creates an exact Duration out of a time.Duration, using only hours, minutes
+// and seconds.
+func DurationOf(v time.Duration) Duration {
+	d := Duration{Negative: v < 0}
+	if v < 0 {
+		v = -v
+	}
+	d.Hours = float64(v / time.Hour)
+	v %= time.Hour
+	d.Minutes = float64(v / time.Minute)
+	v %= time.Minute
+	d.Seconds = float64(v) / float64(time.Second)
+	return d
+}
+
+func decodeDuration(value string, c Constraints) (Duration, error) {
+	d, err := ParseDuration(value)
+	if err != nil {
+		return d, err
+	}
+	return d, checkBounds(d, c, durationOrdering)
 }
 
 func encodeDuration(in interface{}) (string, error) {
-	v, ok := in.(time.Duration)
-	if !ok {
-		return "", fmt.Errorf("invalid duration - value:%v type:%v", in, reflect.ValueOf(in).Type())
-	}
-	y := v / hoursInYear
-	r := v % hoursInYear
-	m := r / hoursInMonth
-	r = r % hoursInMonth
-	d := r / hoursInDay
-	r = r % hoursInDay
-	return strings.ToUpper(fmt.Sprintf("P%dY%dM%dDT%s", y, m, d, r.String())), nil
+	switch v := in.(type) {
+	case Duration:
+		return v.String(), nil
+	case time.Duration:
+		return DurationOf(v).String(), nil
+	}
+	return "", fmt.Errorf("invalid duration - value:%v type:%v", in, reflect.ValueOf(in).Type())
 }
@@ -1,62 +1,217 @@
 package schema
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/matryer/is"
 )
 
-func TestCastDuration_Success(t *testing.T) {
+func TestParseDuration_Success(t *testing.T) {
 	data := []struct {
 		desc  string
 		value string
-		want  time.Duration
+		want  Duration
 	}{
-		{"OnlyP", "P", time.Duration(0)},
-		{"OnlyHour", "P2H", time.Duration(2) * time.Hour},
-		{"SecondsWithDecimal", "P22.519S", 22519 * time.Millisecond},
-		{"HourDefaultZero", "PH", time.Duration(0) * time.Hour},
-		{"OnlyPeriod", "P3Y6M4D", 3*hoursInYear + 6*hoursInMonth + 4*hoursInDay},
-		{"OnlyTime", "PT12H30M5S", 12*time.Hour + 30*time.Minute + 5*time.Second},
-		{"Complex", "P3Y6M4DT12H30M5S", 3*hoursInYear + 6*hoursInMonth + 4*hoursInDay + 12*time.Hour + 30*time.Minute + 5*time.Second},
-		{"2Years", "P2Y", (2 * 360 * 24) * time.Hour},
-		{"StringFieldsAreIgnored", "PfooHdddS", time.Duration(0)},
+		{"OnlyHour", "PT2H", Duration{Hours: 2}},
+		{"SecondsWithDecimal", "PT22.519S", Duration{Seconds: 22.519}},
+		{"DecimalComma", "PT0,5S", Duration{Seconds: 0.5}},
+		{"FractionalHours", "PT1.5H", Duration{Hours: 1.5}},
+		{"FractionalYears", "P0.5Y", Duration{Years: 0.5}},
+		{"OnlyPeriod", "P3Y6M4D", Duration{Years: 3, Months: 6, Days: 4}},
+		{"OnlyTime", "PT12H30M5S", Duration{Hours: 12, Minutes: 30, Seconds: 5}},
+		{"Complex", "P3Y6M4DT12H30M5S", Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5}},
+		{"MonthVsMinute", "P1MT1M", Duration{Months: 1, Minutes: 1}},
+		{"Weeks", "P2W", Duration{Weeks: 2}},
+		{"Negative", "-P1DT2H", Duration{Negative: true, Days: 1, Hours: 2}},
+		{"Positive", "+P1D", Duration{Days: 1}},
+		{"Zero", "PT0S", Duration{}},
+		{"AlternativeExtended", "P0003-06-04T12:30:05", Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5}},
+		{"AlternativeBasic", "P00030604T123005.5", Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5.5}},
 	}
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			got, err := castDuration(d.value)
+			got, err := ParseDuration(d.value)
 			is.NoErr(err)
 			is.Equal(got, d.want)
 		})
 	}
 }
 
-func TestCastDuration_Error(t *testing.T) {
+func TestParseDuration_Error(t *testing.T) {
 	data := []struct {
 		desc  string
 		value string
 	}{
+		{"Empty", ""},
 		{"WrongStartChar", "C2H"},
+		{"OnlyP", "P"},
+		{"OnlyPT", "PT"},
+		{"HourWithoutT", "P2H"},
+		{"DesignatorWithoutNumber", "PH"},
+		{"Garbage", "PfooHdddS"},
+		{"TrailingGarbage", "P1Dfoo"},
+		{"LeadingGarbage", "fooP1D"},
+		{"FractionNotInSmallestUnit", "P1.5DT1H"},
+		{"WrongOrder", "P1D1Y"},
+		{"AlternativeOutOfRange", "P0003-13-04T12:30:05"},
 	}
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			_, err := castDuration(d.value)
+			_, err := ParseDuration(d.value)
 			is.True(err != nil)
 		})
 	}
 }
 
+func TestDuration_String(t *testing.T) {
+	data := []struct {
+		desc  string
+		value Duration
+		want  string
+	}{
+		{"Zero", Duration{}, "PT0S"},
+		{"NegativeZero", Duration{Negative: true}, "PT0S"},
+		{"OmitsZeroComponents", Duration{Hours: 1}, "PT1H"},
+		{"Complex", Duration{Years: 1, Months: 1, Days: 1, Hours: 1, Minutes: 1, Seconds: 0.5}, "P1Y1M1DT1H1M0.5S"},
+		{"Weeks", Duration{Weeks: 3}, "P3W"},
+		{"Negative", Duration{Negative: true, Months: 2}, "-P2M"},
+		{"Fraction", Duration{Hours: 1.25}, "PT1.25H"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(d.value.String(), d.want)
+		})
+	}
+}
+
+func TestDuration_JSON(t *testing.T) {
+	is := is.New(t)
+	var got struct{ D Duration }
+	is.NoErr(json.Unmarshal([]byte(`{"D":"P1Y2W"}`), &got))
+	is.Equal(got.D, Duration{Years: 1, Weeks: 2})
+	b, err := json.Marshal(got)
+	is.NoErr(err)
+	is.Equal(string(b), `{"D":"P1Y2W"}`)
+	is.True(json.Unmarshal([]byte(`{"D":"P"}`), &got) != nil)
+}
+
+func TestDuration_AddTo(t *testing.T) {
+	data := []struct {
+		desc  string
+		value string
+		want  time.Time
+	}{
+		{"Calendar", "P1Y1M1D", time.Date(2018, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{"Clock", "PT25H0.5S", time.Date(2017, 1, 2, 1, 0, 0, 500000000, time.UTC)},
+		{"Weeks", "P2W", time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"FractionalDay", "P1.5D", time.Date(2017, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{"Negative", "-P1MT1H", time.Date(2016, 11, 30, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			v, err := ParseDuration(d.value)
+			is.NoErr(err)
+			is.Equal(v.AddTo(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)), d.want)
+		})
+	}
+}
+
+func TestDuration_Compare(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc string
+			a, b string
+			want int
+		}{
+			{"Equal", "P1D", "PT24H", 0},
+			{"Shorter", "PT59M", "PT1H", -1},
+			{"Longer", "P1Y", "P364D", 1},
+			{"Months", "P1M", "P27D", 1},
+			{"Negative", "-P1D", "PT1S", -1},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				a, _ := ParseDuration(d.a)
+				b, _ := ParseDuration(d.b)
+				got, err := a.Compare(b)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("NotComparable", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Duration{Months: 1}.Compare(Duration{Days: 30})
+		is.True(err != nil)
+	})
+}
+
+func TestDuration_ToDuration(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		is := is.New(t)
+		got, err := Duration{Negative: true, Weeks: 1, Days: 1, Seconds: 22.519}.ToDuration()
+		is.NoErr(err)
+		is.Equal(got, -(8*24*time.Hour + 22519*time.Millisecond))
+	})
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := Duration{Months: 1}.ToDuration()
+		is.True(err != nil)
+	})
+}
+
+func TestDecodeDuration(t *testing.T) {
+	t.Run("ValidMaximum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeDuration("PT1H", Constraints{Maximum: "PT60M"})
+		is.NoErr(err)
+	})
+	t.Run("ValidMinimum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeDuration("P1M", Constraints{Minimum: "P27D"})
+		is.NoErr(err)
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc        string
+			value       string
+			constraints Constraints
+		}{
+			{"InvalidDuration", "foo", Constraints{}},
+			{"BiggerThanMaximum", "PT61M", Constraints{Maximum: "PT1H"}},
+			{"InvalidMaximum", "PT1H", Constraints{Maximum: "boo"}},
+			{"SmallerThanMinimum", "P1D", Constraints{Minimum: "PT25H"}},
+			{"InvalidMinimum", "PT1H", Constraints{Minimum: "boo"}},
+			{"NotComparable", "P1M", Constraints{Maximum: "P30D"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeDuration(d.value, d.constraints)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
 func TestEncodeDuration(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		data := []struct {
 			desc  string
-			value time.Duration
+			value interface{}
 			want  string
 		}{
-			{"1Year", 1*hoursInYear + 1*hoursInMonth + 1*hoursInDay + 1*time.Hour + 1*time.Minute + 500*time.Millisecond, "P1Y1M1DT1H1M0.5S"},
+			{"Duration", Duration{Years: 1, Months: 1, Days: 1, Hours: 1, Minutes: 1, Seconds: 0.5}, "P1Y1M1DT1H1M0.5S"},
+			{"TimeDuration", 26*time.Hour + 1*time.Minute + 500*time.Millisecond, "PT26H1M0.5S"},
+			{"NegativeTimeDuration", -time.Second, "-PT1S"},
+			{"ZeroTimeDuration", time.Duration(0), "PT0S"},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// parseEnum decodes the enum values of fields which are not arrays or objects, whose
+// values are compared as JSON by checkJSONConstraints. Values can either be strings, which
+// are decoded as table cells, or JSON values, e.g. numbers of integer fields.
+func parseEnum(f *Field) ([]interface{}, error) {
+	// Values are decoded without constraints, so the enum is not checked recursively.
+	plain := *f
+	plain.Constraints = Constraints{}
+	plain.MissingValues, plain.MissingValueLabels = nil, nil
+	values := make([]interface{}, len(f.Constraints.Enum))
+	for i, e := range f.Constraints.Enum {
+		var s string
+		var err error
+		switch v := e.(type) {
+		case string:
+			s = v
+		case float64:
+			// JSON numbers, e.g. of year or integer fields.
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			s, err = plain.Encode(e)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum value %v: %v", e, err)
+		}
+		v, err := plain.decodeType(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum value %v: %v", e, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// enumKey identifies how enum values are decoded, so parsed values are only reused for
+// the same type, format and location.
+type enumKey struct {
+	typ      string
+	format   string
+	itemType string
+	order    DateOrder
+	loc      *time.Location
+}
+
+// enumCache holds the parsed enum values of a field. It is shared by the copies of the
+// field, e.g. the ones made by Schema.Decode to apply the schema location, so values are
+// parsed once for each location instead of once per decoded cell.
+type enumCache struct {
+	mu     sync.Mutex
+	values map[enumKey][]interface{}
+}
+
+func (c *enumCache) get(f *Field) ([]interface{}, error) {
+	k := enumKey{typ: f.Type, format: f.Format, itemType: f.ItemType, order: f.DateOrder, loc: f.Location}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if values, ok := c.values[k]; ok {
+		return values, nil
+	}
+	values, err := parseEnum(f)
+	if err != nil {
+		return nil, err
+	}
+	if c.values == nil {
+		c.values = make(map[enumKey][]interface{})
+	}
+	c.values[k] = values
+	return values, nil
+}
+
+// checkEnum checks whether the decoded value is one of the enum values. Values are
+// parsed for each call if the field was not prepared.
+func checkEnum(f *Field, v interface{}) error {
+	var values []interface{}
+	var err error
+	if c := f.Constraints.enum; c != nil {
+		values, err = c.get(f)
+	} else {
+		values, err = parseEnum(f)
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range values {
+		if enumEqual(e, v) {
+			return nil
+		}
+	}
+	return fmt.Errorf("constraint check error: %v not in enum:%v", v, f.Constraints.Enum)
+}
+
+func enumEqual(a, b interface{}) bool {
+	if t, ok := a.(time.Time); ok {
+		u, ok := b.(time.Time)
+		return ok && t.Equal(u)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// usesJSONEnum reports whether the enum of the field type is checked by
+// checkJSONConstraints.
+func usesJSONEnum(typ string) bool {
+	return typ == ArrayType || typ == ObjectType
+}
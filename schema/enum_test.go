@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestEnum(t *testing.T) {
+	data := []struct {
+		desc  string
+		field string
+		value string
+		valid bool
+	}{
+		{"String", `{"name":"f","type":"string","constraints":{"enum":["a","b"]}}`, "b", true},
+		{"StringNotInEnum", `{"name":"f","type":"string","constraints":{"enum":["a","b"]}}`, "c", false},
+		{"IntegerJSONValue", `{"name":"f","type":"integer","constraints":{"enum":[1,1000000]}}`, "1000000", true},
+		{"IntegerString", `{"name":"f","type":"integer","constraints":{"enum":["1","2"]}}`, "2", true},
+		{"IntegerNotInEnum", `{"name":"f","type":"integer","constraints":{"enum":[1,2]}}`, "3", false},
+		{"Number", `{"name":"f","type":"number","constraints":{"enum":[1.5]}}`, "1.50", true},
+		{"Boolean", `{"name":"f","type":"boolean","constraints":{"enum":[true]}}`, "false", false},
+		{"Date", `{"name":"f","type":"date","constraints":{"enum":["2017-01-01"]}}`, "2017-01-01", true},
+		{"DateNotInEnum", `{"name":"f","type":"date","constraints":{"enum":["2017-01-01"]}}`, "2017-01-02", false},
+		{"DateTimeOffset", `{"name":"f","type":"datetime","constraints":{"enum":["2017-01-01T10:00:00Z"]}}`, "2017-01-01T12:00:00+02:00", true},
+		{"Year", `{"name":"f","type":"year","constraints":{"enum":[2017]}}`, "2016", false},
+		{"List", `{"name":"f","type":"list","itemType":"integer","constraints":{"enum":["1,2",[3,4]]}}`, "3,4", true},
+		{"ListNotInEnum", `{"name":"f","type":"list","itemType":"integer","constraints":{"enum":["1,2"]}}`, "2,1", false},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			var f Field
+			is.NoErr(json.Unmarshal([]byte(d.field), &f))
+			_, err := f.Decode(d.value)
+			is.Equal(err == nil, d.valid)
+		})
+	}
+	t.Run("CodeBuiltField", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Type: IntegerType, Constraints: Constraints{Enum: []interface{}{1, "2"}}}
+		_, err := f.Decode("2")
+		is.NoErr(err)
+		_, err = f.Decode("3")
+		is.True(err != nil)
+	})
+	t.Run("MissingValue", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Type: IntegerType, MissingValues: missingValuesSet([]string{""}), Constraints: Constraints{Enum: []interface{}{1}}}
+		_, err := f.Decode("")
+		is.NoErr(err) // missing values are not checked against the enum
+	})
+	t.Run("InvalidEnum", func(t *testing.T) {
+		is := is.New(t)
+		var f Field
+		is.True(json.Unmarshal([]byte(`{"name":"f","type":"integer","constraints":{"enum":["a"]}}`), &f) != nil)
+		f = Field{Type: IntegerType, Constraints: Constraints{Enum: []interface{}{"a"}}}
+		_, err := f.Decode("1")
+		is.True(err != nil)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"time"
 )
 
 // Default for schema fields.
@@ -37,6 +38,8 @@ const (
 	YearType      = "year"
 	DurationType  = "duration"
 	GeoPointType  = "geopoint"
+	GeoJSONType   = "geojson"
+	ListType      = "list"
 	AnyType       = "any"
 )
 
@@ -53,12 +56,27 @@ type Constraints struct {
 	// represent null values.
 	Required bool `json:"required,omitempty"`
 
-	Maximum         string `json:"maximum,omitempty"`
-	Minimum         string `json:"minimum,omitempty"`
+	// Bounds of ordered types (e.g. integer, date or duration). Values are written using
+	// the field type and format. Date and datetime bounds can also be relative to the
+	// current time, for instance "now" or "now-P1Y".
+	Maximum          string `json:"maximum,omitempty"`
+	Minimum          string `json:"minimum,omitempty"`
+	ExclusiveMaximum string `json:"exclusiveMaximum,omitempty"`
+	ExclusiveMinimum string `json:"exclusiveMinimum,omitempty"`
+	bounds           *bounds
+
 	MinLength       int    `json:"minLength,omitempty"`
 	MaxLength       int    `json:"maxLength,omitempty"`
 	Pattern         string `json:"pattern,omitempty"`
 	compiledPattern *regexp.Regexp
+
+	// Enum restricts values to the listed ones. Values can either be JSON values or
+	// strings, which are decoded as cells (as JSON for array and object fields).
+	Enum []interface{} `json:"enum,omitempty"`
+	enum *enumCache
+	// JSONSchema is a JSON Schema which array and object values must be valid against.
+	JSONSchema         json.RawMessage `json:"jsonSchema,omitempty"`
+	compiledJSONSchema *jsonSchema
 }
 
 // Field describes a single field in the table schema.
@@ -78,6 +96,12 @@ type Field struct {
 	TrueValues  []string `json:"trueValues,omitempty"`
 	FalseValues []string `json:"falseValues,omitempty"`
 
+	// String properties.
+
+	// UUIDVersion restricts values of "uuid" format strings to the given RFC 4122
+	// version. If zero, all versions are accepted.
+	UUIDVersion int `json:"uuidVersion,omitempty"`
+
 	// Number/Integer properties.
 
 	// A string whose value is used to represent a decimal point within the number. The default value is ".".
@@ -89,9 +113,56 @@ type Field struct {
 	// are going to be stripped. Default value is true:
 	BareNumber bool `json:"bareNumber,omitempty"`
 
+	// Date/DateTime/Time properties.
+
+	// DateOrder defines how numeric dates like "01/02/2017" are interpreted when the field
+	// format is "any". If not set, values which could be read both as day first and month
+	// first are reported as errors (*AmbiguousDateError).
+	DateOrder DateOrder `json:"dateOrder,omitempty"`
+	// Location is used to interpret datetime and time values which do not have an UTC
+	// offset, for instance "2017-12-31T13:45:00". If nil, Schema.Location is used when
+	// decoding through the schema, falling back to UTC.
+	Location *time.Location `json:"-"`
+	// KeepOffset indicates whether decoded datetime and time values should keep the offset
+	// they were written with (or Location, for values without offset). By default, those
+	// values are converted to UTC.
+	KeepOffset bool `json:"-"`
+	// Clock returns the current time, which date and datetime bounds relative to "now" are
+	// evaluated against. If nil, Schema.Clock is used when decoding through the schema,
+	// falling back to time.Now.
+	Clock func() time.Time `json:"-"`
+
+	// Categorical properties, for string and integer fields.
+	// https://datapackage.org/standard/table-schema/#categories
+
+	// Categories lists the values allowed by the field, optionally with labels.
+	Categories Categories `json:"categories,omitempty"`
+	// CategoriesOrdered indicates whether the categories are ordered, in which case
+	// minimum and maximum constraints are categories compared by position.
+	CategoriesOrdered bool `json:"categoriesOrdered,omitempty"`
+	// CategoriesAsLabels makes Decode return the category label instead of its value,
+	// for categories which have labels.
+	CategoriesAsLabels bool `json:"-"`
+
+	// List properties.
+	// https://datapackage.org/standard/table-schema/#list
+
+	// Delimiter separates the items of list cells. The default value is ",".
+	Delimiter string `json:"delimiter,omitempty"`
+	// ItemType is the type of the list items, which can be string (default), integer,
+	// number, boolean, date, datetime or time.
+	ItemType string `json:"itemType,omitempty"`
+
 	// MissingValues is a map which dictates which string values should be treated as null
-	// values.
+	// values. It overrides Schema.MissingValues, which apply if it is nil.
 	MissingValues map[string]struct{} `json:"-"`
+	// MissingValueLabels maps missing values to their labels, e.g. "-99" to "refused".
+	MissingValueLabels map[string]string `json:"-"`
+	// MissingValuesPlaceholder holds the missingValues property, which can be either a list
+	// of strings or a list of objects with value and label.
+	MissingValuesPlaceholder interface{} `json:"missingValues,omitempty"`
+	// Whether MissingValues have been copied from the schema.
+	inheritedMissingValues bool
 
 	// Constraints can be used by consumers to list constraints for validating
 	// field values.
@@ -103,11 +174,12 @@ type Field struct {
 func (f *Field) UnmarshalJSON(data []byte) error {
 	// This is neded so it does not call UnmarshalJSON from recursively.
 	type fieldAlias Field
+	// Copying default slices, otherwise json.Unmarshal would overwrite their contents.
 	u := &fieldAlias{
 		Type:        defaultFieldType,
 		Format:      defaultFieldFormat,
-		TrueValues:  defaultTrueValues,
-		FalseValues: defaultFalseValues,
+		TrueValues:  append([]string(nil), defaultTrueValues...),
+		FalseValues: append([]string(nil), defaultFalseValues...),
 		DecimalChar: defaultDecimalChar,
 		GroupChar:   defaultGroupChar,
 		BareNumber:  defaultBareNumber,
@@ -117,6 +189,16 @@ func (f *Field) UnmarshalJSON(data []byte) error {
 	}
 	*f = Field(*u)
 
+	if f.MissingValuesPlaceholder != nil {
+		values, labels, err := parseMissingValues(f.MissingValuesPlaceholder)
+		if err != nil {
+			return err
+		}
+		f.MissingValues = missingValuesSet(values)
+		f.MissingValueLabels = labels
+		f.MissingValuesPlaceholder = nil
+	}
+
 	if f.Constraints.Pattern != "" {
 		p, err := regexp.Compile(f.Constraints.Pattern)
 		if err != nil {
@@ -124,48 +206,118 @@ func (f *Field) UnmarshalJSON(data []byte) error {
 		}
 		f.Constraints.compiledPattern = p
 	}
+	if len(f.Constraints.JSONSchema) > 0 {
+		s, err := compileJSONSchema(f.Constraints.JSONSchema)
+		if err != nil {
+			return err
+		}
+		f.Constraints.compiledJSONSchema = s
+	}
+	if len(f.Constraints.Enum) > 0 && !usesJSONEnum(f.Type) {
+		if _, err := parseEnum(f); err != nil {
+			return err
+		}
+	}
+	f.prepare()
 	return nil
 }
 
+// prepare parses the bounds and enum constraints, so they are not parsed again for
+// each decoded value. Invalid constraints are reported when decoding.
+func (f *Field) prepare() {
+	if o, ok := f.ordering(); ok && f.Constraints.hasBounds() {
+		if b := f.Constraints.bounds; b == nil || b.key != o.withConstraints(f.Constraints) {
+			f.Constraints.bounds, _ = parseBounds(f.Constraints, o)
+		}
+	}
+	if len(f.Constraints.Enum) > 0 && !usesJSONEnum(f.Type) {
+		// Enum values depend on other properties, e.g. the location of datetimes, so
+		// they are cached for each of them.
+		f.Constraints.enum = &enumCache{}
+		f.Constraints.enum.get(f)
+	}
+}
+
+// MarshalJSON implements json.Marshaler. Missing values copied from the schema
+// are not written.
+func (f Field) MarshalJSON() ([]byte, error) {
+	type fieldAlias Field
+	a := fieldAlias(f)
+	if f.MissingValues != nil && !f.inheritedMissingValues {
+		a.MissingValuesPlaceholder = missingValuesPlaceholder(sortedMissingValues(f.MissingValues), f.MissingValueLabels)
+	}
+	return json.Marshal(a)
+}
+
 // Decode decodes the passed-in string against field type. Returns an error
 // if the value can not be cast or any field constraint can not be satisfied.
+// Missing values are decoded as MissingValue.
 func (f *Field) Decode(value string) (interface{}, error) {
-	if f.Constraints.Required {
-		_, ok := f.MissingValues[value]
-		if ok {
+	if mv, ok := f.missingValue(value); ok {
+		if f.Constraints.Required {
 			return nil, fmt.Errorf("%s is required", f.Name)
 		}
+		return mv, nil
+	}
+	v, err := f.decodeType(value)
+	if err == nil && len(f.Constraints.Enum) > 0 && !usesJSONEnum(f.Type) {
+		err = checkEnum(f, v)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return v, nil
+}
+
+// decodeType decodes the value according to the field type, checking all constraints
+// but the enum of types other than array and object.
+func (f *Field) decodeType(value string) (interface{}, error) {
 	switch f.Type {
 	case IntegerType:
+		if len(f.Categories) > 0 {
+			return decodeCategorical(f, value)
+		}
 		return castInt(f.BareNumber, value, f.Constraints)
 	case StringType:
-		return decodeString(f.Format, value, f.Constraints)
+		if len(f.Categories) > 0 {
+			return decodeCategorical(f, value)
+		}
+		if f.Format == stringBinary {
+			return decodeBinary(value, f.Constraints)
+		}
+		return decodeString(f.Format, f.UUIDVersion, value, f.Constraints)
 	case BooleanType:
 		return castBoolean(value, f.TrueValues, f.FalseValues)
 	case NumberType:
 		return castNumber(f.DecimalChar, f.GroupChar, f.BareNumber, value, f.Constraints)
 	case DateType:
-		return decodeDate(f.Format, value, f.Constraints)
+		return decodeDate(f.Format, f.temporalOptions(), value, f.Constraints)
 	case ObjectType:
-		return castObject(value)
+		return castObject(value, f.Constraints)
 	case ArrayType:
-		return castArray(value)
+		return castArray(value, f.Constraints)
 	case TimeType:
-		return decodeTime(f.Format, value, f.Constraints)
+		return decodeTime(f.Format, f.temporalOptions(), value, f.Constraints)
 	case YearMonthType:
 		return decodeYearMonth(value, f.Constraints)
 	case YearType:
 		return decodeYear(value, f.Constraints)
 	case DateTimeType:
-		return decodeDateTime(value, f.Constraints)
+		return decodeDateTime(f.Format, f.temporalOptions(), value, f.Constraints)
 	case DurationType:
-		return castDuration(value)
+		return decodeDuration(value, f.Constraints)
 	case GeoPointType:
-		return castGeoPoint(f.Format, value)
+		return castGeoPoint(f.Format, value, f.Constraints)
+	case GeoJSONType:
+		return decodeGeoJSON(f.Format, value)
+	case ListType:
+		return decodeList(f, value)
 	case AnyType:
 		return castAny(value)
 	}
+	if t, ok := lookupType(f.Type); ok {
+		return decodeCustomType(t, f, value)
+	}
 	return nil, fmt.Errorf("invalid field type: %s", f.Type)
 }
 
@@ -176,6 +328,14 @@ func (f *Field) Encode(in interface{}) (string, error) {
 	inValue := reflect.Indirect(reflect.ValueOf(in))
 	inInterface := inValue.Interface()
 	ok := false
+	if mv, isMissing := inInterface.(MissingValue); isMissing {
+		return mv.Value, nil
+	}
+	if len(f.Categories) > 0 {
+		if v, found := encodeCategoryLabel(f, inInterface); found {
+			return v, nil
+		}
+	}
 	switch f.Type {
 	case IntegerType:
 		var a int64
@@ -194,17 +354,31 @@ func (f *Field) Encode(in interface{}) (string, error) {
 	case DurationType:
 		return encodeDuration(inInterface)
 	case GeoPointType:
-		return encodeGeoPoint(f.Format, in)
-	case DateType, DateTimeType, TimeType, YearMonthType, YearType:
-		return encodeTime(inInterface)
+		return encodeGeoPoint(f.Format, inInterface)
+	case GeoJSONType:
+		return encodeGeoJSON(f.Format, inInterface)
+	case ListType:
+		return encodeList(f, inInterface)
+	case DateType, DateTimeType:
+		return encodeTime(f.Format, inInterface)
+	case TimeType:
+		return encodeTimeOfDay(f.Format, inInterface)
+	case YearType:
+		return encodeYear(inInterface)
+	case YearMonthType:
+		return encodeYearMonth(inInterface)
 	case ObjectType:
 		return encodeObject(inInterface)
 	case StringType:
-		_, ok = inInterface.(string)
+		return encodeString(f.Format, inInterface)
 	case ArrayType:
 		ok = reflect.TypeOf(inInterface).Kind() == reflect.Slice
 	case AnyType:
 		return encodeAny(in)
+	default:
+		if t, found := lookupType(f.Type); found {
+			return t.Encode(f, inInterface)
+		}
 	}
 	if !ok {
 		return "", fmt.Errorf("can not convert \"%d\" which type is %s to type %s", in, reflect.TypeOf(in), f.Type)
@@ -212,6 +386,10 @@ func (f *Field) Encode(in interface{}) (string, error) {
 	return fmt.Sprintf("%v", inInterface), nil
 }
 
+func (f *Field) temporalOptions() temporalOptions {
+	return temporalOptions{order: f.DateOrder, loc: f.Location, keepOffset: f.KeepOffset, now: f.Clock}
+}
+
 // TestString checks whether the value can be unmarshalled to the field type.
 func (f *Field) TestString(value string) bool {
 	_, err := f.Decode(value)
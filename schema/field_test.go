@@ -52,15 +52,22 @@ func TestField_Decode(t *testing.T) {
 		{"Date_NoFormat", "2015-10-15", Field{Type: DateType}, time.Date(2015, time.October, 15, 0, 0, 0, 0, time.UTC)},
 		{"Date_DefaultFormat", "2015-10-15", Field{Type: DateType, Format: defaultFieldFormat}, time.Date(2015, time.October, 15, 0, 0, 0, 0, time.UTC)},
 		{"Date_CustomFormat", "15/10/2015", Field{Type: DateType, Format: "%d/%m/%Y"}, time.Date(2015, time.October, 15, 0, 0, 0, 0, time.UTC)},
-		{"Time_NoFormat", "10:10:10", Field{Type: TimeType}, time.Date(0000, time.January, 01, 10, 10, 10, 00, time.UTC)},
-		{"Time_DefaultFormat", "10:10:10", Field{Type: TimeType, Format: defaultFieldFormat}, time.Date(0000, time.January, 01, 10, 10, 10, 00, time.UTC)},
-		{"Time_CustomFormat", "10-10-10", Field{Type: TimeType, Format: "%H-%M-%S"}, time.Date(0000, time.January, 01, 10, 10, 10, 00, time.UTC)},
-		{"YearMonth", "2017-08", Field{Type: YearMonthType}, time.Date(2017, time.August, 01, 00, 00, 00, 00, time.UTC)},
-		{"Year", "2017", Field{Type: YearType}, time.Date(2017, time.January, 01, 00, 00, 00, 00, time.UTC)},
+		{"Time_NoFormat", "10:10:10", Field{Type: TimeType}, TimeOfDay{Hour: 10, Minute: 10, Second: 10}},
+		{"Time_DefaultFormat", "15:10:10", Field{Type: TimeType, Format: defaultFieldFormat}, TimeOfDay{Hour: 15, Minute: 10, Second: 10}},
+		{"Time_CustomFormat", "10-10-10", Field{Type: TimeType, Format: "%H-%M-%S"}, TimeOfDay{Hour: 10, Minute: 10, Second: 10}},
+		{"YearMonth", "2017-08", Field{Type: YearMonthType}, YearMonth{2017, time.August}},
+		{"Year", "2017", Field{Type: YearType}, Year(2017)},
 		{"DateTime_NoFormat", "2008-09-15T10:53:00Z", Field{Type: DateTimeType}, time.Date(2008, time.September, 15, 10, 53, 00, 00, time.UTC)},
 		{"DateTime_DefaultFormat", "2008-09-15T10:53:00Z", Field{Type: DateTimeType, Format: defaultFieldFormat}, time.Date(2008, time.September, 15, 10, 53, 00, 00, time.UTC)},
-		{"Duration", "P2H", Field{Type: DurationType}, 2 * time.Hour},
+		{"Date_AnyFormat", "31 Dec 2017", Field{Type: DateType, Format: AnyDateFormat}, time.Date(2017, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{"Date_AnyFormatDayFirst", "01/02/2017", Field{Type: DateType, Format: AnyDateFormat, DateOrder: DayFirst}, time.Date(2017, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"Time_AnyFormat", "1:45 PM", Field{Type: TimeType, Format: AnyDateFormat}, TimeOfDay{Hour: 13, Minute: 45}},
+		{"DateTime_AnyFormat", "12/31/2017 13:45", Field{Type: DateTimeType, Format: AnyDateFormat}, time.Date(2017, time.December, 31, 13, 45, 00, 00, time.UTC)},
+		{"Duration", "PT2H", Field{Type: DurationType}, Duration{Hours: 2}},
 		{"GeoPoint", "90,45", Field{Type: GeoPointType}, GeoPoint{90, 45}},
+		{"GeoJSON", `{"type":"Point","coordinates":[90,45]}`, Field{Type: GeoJSONType}, Geometry{Type: GeoJSONPoint, Point: Position{90, 45}}},
+		{"Binary", "Zm9vYg==", Field{Type: StringType, Format: stringBinary}, []byte("foob")},
+		{"UUID", "6fa459ea-ee8a-3ca4-894e-db77e160355e", Field{Type: StringType, Format: stringUUID, UUIDVersion: 3}, "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
 		{"Any", "10", Field{Type: AnyType}, "10"},
 	}
 	for _, d := range data {
@@ -111,7 +118,7 @@ func TestField_Decode(t *testing.T) {
 			field Field
 			value string
 		}{
-			{"InvalidFormat_Any", Field{Type: DateType, Format: "any"}, "2015-10-15"},
+			{"Any_Ambiguous", Field{Type: DateType, Format: AnyDateFormat}, "01/02/2015"},
 			{"InvalidFormat_Strftime", Field{Type: DateType, Format: "Fooo"}, "2015-10-15"},
 		}
 		for _, d := range data {
@@ -135,6 +142,25 @@ func TestField_Decode(t *testing.T) {
 			_, err := f.Decode("NA")
 			is.True(err != nil)
 		})
+		t.Run("JSONSchema", func(t *testing.T) {
+			is := is.New(t)
+			var f Field
+			is.NoErr(json.Unmarshal([]byte(`{"name":"payload","type":"object","constraints":{"jsonSchema":{"required":["id"]}}}`), &f))
+			is.True(f.Constraints.compiledJSONSchema != nil)
+			_, err := f.Decode(`{"id":1}`)
+			is.NoErr(err)
+			_, err = f.Decode(`{"name":"foo"}`)
+			is.True(err != nil)
+		})
+		t.Run("Enum", func(t *testing.T) {
+			is := is.New(t)
+			var f Field
+			is.NoErr(json.Unmarshal([]byte(`{"name":"tags","type":"array","constraints":{"enum":[["a"],"[\"b\"]"]}}`), &f))
+			_, err := f.Decode(`["b"]`)
+			is.NoErr(err)
+			_, err = f.Decode(`["c"]`)
+			is.True(err != nil)
+		})
 	})
 }
 
@@ -142,6 +168,7 @@ func TestUnmarshalJSON_InvalidField(t *testing.T) {
 	is := is.New(t)
 	var f Field
 	is.True(json.Unmarshal([]byte("{Foo:1}"), &f) != nil)
+	is.True(json.Unmarshal([]byte(`{"name":"foo","constraints":{"jsonSchema":{"type":1}}}`), &f) != nil)
 }
 
 func TestTestString(t *testing.T) {
@@ -164,15 +191,24 @@ func TestField_Encode(t *testing.T) {
 			{"IntNumberImplicitCast", Field{Type: NumberType}, 100, "100"},
 			{"NumberToIntImplicitCast", Field{Type: IntegerType}, 100.5, "100"},
 			{"Boolean", Field{Type: BooleanType}, true, "true"},
-			{"Duration", Field{Type: DurationType}, 1 * time.Second, "P0Y0M0DT1S"},
+			{"Duration", Field{Type: DurationType}, Duration{Days: 1, Seconds: 1}, "P1DT1S"},
+			{"TimeDuration", Field{Type: DurationType}, 1 * time.Second, "PT1S"},
 			{"GeoPoint", Field{Type: GeoPointType}, "10,10", "10,10"},
+			{"GeoPointStruct", Field{Type: GeoPointType, Format: GeoPointArrayFormat}, GeoPoint{10, 10}, "[10,10]"},
+			{"GeoJSON", Field{Type: GeoJSONType}, &Geometry{Type: GeoJSONPoint, Point: Position{90, 45}}, `{"type":"Point","coordinates":[90,45]}`},
+			{"GeoPointStructPointer", Field{Type: GeoPointType, Format: GeoPointObjectFormat}, &GeoPoint{10, 10}, `{"lon":10,"lat":10}`},
 			{"String", Field{Type: StringType}, "foo", "foo"},
+			{"Binary", Field{Type: StringType, Format: stringBinary}, []byte("foob"), "Zm9vYg=="},
 			{"Array", Field{Type: ArrayType}, []string{"foo"}, "[foo]"},
 			{"Date", Field{Type: DateType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
-			{"Year", Field{Type: YearType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
-			{"YearMonth", Field{Type: YearMonthType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
+			{"Year", Field{Type: YearType}, Year(2017), "2017"},
+			{"YearInt", Field{Type: YearType}, 2017, "2017"},
+			{"YearMonth", Field{Type: YearMonthType}, YearMonth{2017, time.May}, "2017-05"},
 			{"DateTime", Field{Type: DateTimeType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
 			{"Date", Field{Type: DateType}, time.Unix(1, 0), "1970-01-01T00:00:01Z"},
+			{"Date_CustomFormat", Field{Type: DateType, Format: "%d/%m/%Y"}, time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC), "31/12/2017"},
+			{"Time", Field{Type: TimeType}, TimeOfDay{Hour: 13, Minute: 45}, "13:45:00"},
+			{"Time_CustomFormat", Field{Type: TimeType, Format: "%-I:%M %p"}, TimeOfDay{Hour: 13, Minute: 45}, "1:45 PM"},
 			{"Object", Field{Type: ObjectType}, eoStruct{Name: "Foo"}, `{"name":"Foo"}`},
 			{"Any", Field{Type: AnyType}, "10", "10"},
 		}
@@ -194,6 +230,7 @@ func TestField_Encode(t *testing.T) {
 			{"StringToIntCast", Field{Type: IntegerType}, "1.5"},
 			{"StringToNumberCast", Field{Type: NumberType}, "1.5"},
 			{"InvalidType", Field{Type: "Boo"}, "1"},
+			{"UnsupportedDirective", Field{Type: DateType, Format: "%Y-%Q"}, time.Unix(1, 0)},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
@@ -0,0 +1,416 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Formats specific to GeoJSON field type.
+const (
+	GeoJSONTopoJSONFormat = "topojson"
+)
+
+// GeoJSON object types.
+// More at: https://tools.ietf.org/html/rfc7946#section-1.4
+const (
+	GeoJSONPoint              = "Point"
+	GeoJSONMultiPoint         = "MultiPoint"
+	GeoJSONLineString         = "LineString"
+	GeoJSONMultiLineString    = "MultiLineString"
+	GeoJSONPolygon            = "Polygon"
+	GeoJSONMultiPolygon       = "MultiPolygon"
+	GeoJSONGeometryCollection = "GeometryCollection"
+	GeoJSONFeature            = "Feature"
+	GeoJSONFeatureCollection  = "FeatureCollection"
+)
+
+// Position is a GeoJSON position: longitude, latitude and, optionally, altitude.
+type Position []float64
+
+// Geometry represents a GeoJSON geometry. Only the coordinates field matching
+// Type is used, for instance Polygon for "Polygon" geometries. Geometries is
+// used by "GeometryCollection".
+// More at: https://tools.ietf.org/html/rfc7946#section-3.1
+type Geometry struct {
+	Type            string
+	Point           Position
+	MultiPoint      []Position
+	LineString      []Position
+	MultiLineString [][]Position
+	Polygon         [][]Position
+	MultiPolygon    [][][]Position
+	Geometries      []Geometry
+	BBox            []float64
+}
+
+// Feature represents a GeoJSON feature. Geometry is nil for unlocated features.
+// More at: https://tools.ietf.org/html/rfc7946#section-3.2
+type Feature struct {
+	// ID is either nil, a string or a number.
+	ID         interface{}
+	Geometry   *Geometry
+	Properties map[string]interface{}
+	BBox       []float64
+}
+
+// FeatureCollection represents a GeoJSON feature collection.
+// More at: https://tools.ietf.org/html/rfc7946#section-3.3
+type FeatureCollection struct {
+	Features []Feature
+	BBox     []float64
+}
+
+// Validate checks whether g is a structurally valid GeoJSON geometry.
+func (g Geometry) Validate() error {
+	switch g.Type {
+	case GeoJSONPoint:
+		if err := validatePosition(g.Point); err != nil {
+			return err
+		}
+	case GeoJSONMultiPoint:
+		for _, p := range g.MultiPoint {
+			if err := validatePosition(p); err != nil {
+				return err
+			}
+		}
+	case GeoJSONLineString:
+		if err := validateLineString(g.LineString); err != nil {
+			return err
+		}
+	case GeoJSONMultiLineString:
+		for _, l := range g.MultiLineString {
+			if err := validateLineString(l); err != nil {
+				return err
+			}
+		}
+	case GeoJSONPolygon:
+		if err := validatePolygon(g.Polygon); err != nil {
+			return err
+		}
+	case GeoJSONMultiPolygon:
+		for _, p := range g.MultiPolygon {
+			if err := validatePolygon(p); err != nil {
+				return err
+			}
+		}
+	case GeoJSONGeometryCollection:
+		for _, c := range g.Geometries {
+			if err := c.Validate(); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("invalid geojson: unknown geometry type:\"%s\"", g.Type)
+	}
+	return validateBBox(g.BBox)
+}
+
+func validatePosition(p Position) error {
+	if len(p) < 2 {
+		return fmt.Errorf("invalid geojson: position %v must have at least two elements", p)
+	}
+	return nil
+}
+
+func validateLineString(l []Position) error {
+	if len(l) < 2 {
+		return fmt.Errorf("invalid geojson: line string must have at least two positions")
+	}
+	for _, p := range l {
+		if err := validatePosition(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePolygon checks the linear rings of a polygon, which must be closed
+// (first and last positions are equivalent) and have at least four positions.
+func validatePolygon(rings [][]Position) error {
+	for _, r := range rings {
+		if len(r) < 4 {
+			return fmt.Errorf("invalid geojson: linear ring must have at least four positions")
+		}
+		for _, p := range r {
+			if err := validatePosition(p); err != nil {
+				return err
+			}
+		}
+		if !reflect.DeepEqual(r[0], r[len(r)-1]) {
+			return fmt.Errorf("invalid geojson: linear ring must be closed, first position %v differs from last %v", r[0], r[len(r)-1])
+		}
+	}
+	return nil
+}
+
+func validateBBox(b []float64) error {
+	if len(b) != 0 && (len(b) < 4 || len(b)%2 != 0) {
+		return fmt.Errorf("invalid geojson: bbox %v must have 2*n elements, n being the number of dimensions", b)
+	}
+	return nil
+}
+
+func (g *Geometry) coordinates() interface{} {
+	switch g.Type {
+	case GeoJSONPoint:
+		return &g.Point
+	case GeoJSONMultiPoint:
+		return &g.MultiPoint
+	case GeoJSONLineString:
+		return &g.LineString
+	case GeoJSONMultiLineString:
+		return &g.MultiLineString
+	case GeoJSONPolygon:
+		return &g.Polygon
+	case GeoJSONMultiPolygon:
+		return &g.MultiPolygon
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	out := struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates,omitempty"`
+		Geometries  interface{} `json:"geometries,omitempty"`
+		BBox        []float64   `json:"bbox,omitempty"`
+	}{Type: g.Type, Coordinates: g.coordinates(), BBox: g.BBox}
+	if g.Type == GeoJSONGeometryCollection {
+		geometries := g.Geometries
+		if geometries == nil {
+			geometries = []Geometry{}
+		}
+		out.Geometries = geometries
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Foreign members are ignored.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometries  []Geometry      `json:"geometries"`
+		BBox        []float64       `json:"bbox"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid geojson: %v", err)
+	}
+	geo := Geometry{Type: raw.Type, BBox: raw.BBox}
+	if raw.Type == GeoJSONGeometryCollection {
+		if raw.Geometries == nil {
+			return fmt.Errorf("invalid geojson: geometry collection must have geometries")
+		}
+		geo.Geometries = raw.Geometries
+	} else if coords := geo.coordinates(); coords != nil {
+		if raw.Coordinates == nil {
+			return fmt.Errorf("invalid geojson: geometry %s must have coordinates", raw.Type)
+		}
+		if err := json.Unmarshal(raw.Coordinates, coords); err != nil {
+			return fmt.Errorf("invalid geojson: %s coordinates: %v", raw.Type, err)
+		}
+	}
+	if err := geo.Validate(); err != nil {
+		return err
+	}
+	*g = geo
+	return nil
+}
+
+// Validate checks whether f is a structurally valid GeoJSON feature.
+func (f Feature) Validate() error {
+	switch reflect.ValueOf(f.ID).Kind() {
+	case reflect.Invalid, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		return fmt.Errorf("invalid geojson: feature id must be a string or a number, got %v", f.ID)
+	}
+	if f.Geometry != nil {
+		if err := f.Geometry.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateBBox(f.BBox)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f Feature) MarshalJSON() ([]byte, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		ID         interface{}            `json:"id,omitempty"`
+		Geometry   *Geometry              `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+		BBox       []float64              `json:"bbox,omitempty"`
+	}{GeoJSONFeature, f.ID, f.Geometry, f.Properties, f.BBox})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Foreign members are ignored.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type       string          `json:"type"`
+		ID         interface{}     `json:"id"`
+		Geometry   json.RawMessage `json:"geometry"`
+		Properties json.RawMessage `json:"properties"`
+		BBox       []float64       `json:"bbox"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid geojson: %v", err)
+	}
+	if raw.Type != GeoJSONFeature {
+		return fmt.Errorf("invalid geojson: expected type %s, got:\"%s\"", GeoJSONFeature, raw.Type)
+	}
+	if raw.Geometry == nil || raw.Properties == nil {
+		return fmt.Errorf("invalid geojson: feature must have geometry and properties members")
+	}
+	feat := Feature{ID: raw.ID, BBox: raw.BBox}
+	if string(raw.Geometry) != "null" {
+		feat.Geometry = &Geometry{}
+		if err := json.Unmarshal(raw.Geometry, feat.Geometry); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(raw.Properties, &feat.Properties); err != nil {
+		return fmt.Errorf("invalid geojson: feature properties: %v", err)
+	}
+	if err := feat.Validate(); err != nil {
+		return err
+	}
+	*f = feat
+	return nil
+}
+
+// Validate checks whether fc is a structurally valid GeoJSON feature collection.
+func (fc FeatureCollection) Validate() error {
+	for _, f := range fc.Features {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateBBox(fc.BBox)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (fc FeatureCollection) MarshalJSON() ([]byte, error) {
+	if err := fc.Validate(); err != nil {
+		return nil, err
+	}
+	features := fc.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+		BBox     []float64 `json:"bbox,omitempty"`
+	}{GeoJSONFeatureCollection, features, fc.BBox})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Foreign members are ignored.
+func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+		BBox     []float64 `json:"bbox"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Type != GeoJSONFeatureCollection {
+		return fmt.Errorf("invalid geojson: expected type %s, got:\"%s\"", GeoJSONFeatureCollection, raw.Type)
+	}
+	if raw.Features == nil {
+		return fmt.Errorf("invalid geojson: feature collection must have features")
+	}
+	c := FeatureCollection{Features: raw.Features, BBox: raw.BBox}
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	*fc = c
+	return nil
+}
+
+// decodeGeoJSON decodes a "geojson" cell. The default format results in a Geometry,
+// Feature or FeatureCollection, depending on the object type. The topojson format
+// results in a Topology.
+func decodeGeoJSON(format, value string) (interface{}, error) {
+	switch format {
+	case "", defaultFieldFormat:
+		return parseGeoJSON([]byte(value))
+	case GeoJSONTopoJSONFormat:
+		var t Topology
+		if err := json.Unmarshal([]byte(value), &t); err != nil {
+			return nil, err
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("invalid geojson format:%s", format)
+}
+
+func parseGeoJSON(data []byte) (interface{}, error) {
+	var obj struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("invalid geojson: %v", err)
+	}
+	switch obj.Type {
+	case GeoJSONFeature:
+		var f Feature
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case GeoJSONFeatureCollection:
+		var fc FeatureCollection
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return nil, err
+		}
+		return fc, nil
+	}
+	var g Geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// encodeGeoJSON encodes the passed-in value as compact JSON. Strings are decoded
+// (and thus validated) before being encoded.
+func encodeGeoJSON(format string, in interface{}) (string, error) {
+	v := in
+	if s, ok := in.(string); ok {
+		var err error
+		if v, err = decodeGeoJSON(format, s); err != nil {
+			return "", err
+		}
+	}
+	switch format {
+	case "", defaultFieldFormat:
+		switch v.(type) {
+		case Geometry, Feature, FeatureCollection:
+		default:
+			return "", fmt.Errorf("invalid geojson - value:%v type:%v", in, reflect.TypeOf(in))
+		}
+	case GeoJSONTopoJSONFormat:
+		if _, ok := v.(Topology); !ok {
+			return "", fmt.Errorf("invalid topojson - value:%v type:%v", in, reflect.TypeOf(in))
+		}
+	default:
+		return "", fmt.Errorf("invalid geojson format:%s", format)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
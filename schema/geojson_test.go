@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+var square = []Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+
+func TestDecodeGeoJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			want  interface{}
+		}{
+			{"Point", `{"type":"Point","coordinates":[100.0,0.0]}`, Geometry{Type: GeoJSONPoint, Point: Position{100, 0}}},
+			{"PointWithAltitude", `{"type":"Point","coordinates":[100.0,0.0,10.5]}`, Geometry{Type: GeoJSONPoint, Point: Position{100, 0, 10.5}}},
+			{"MultiPoint", `{"type":"MultiPoint","coordinates":[[100,0],[101,1]]}`, Geometry{Type: GeoJSONMultiPoint, MultiPoint: []Position{{100, 0}, {101, 1}}}},
+			{"LineString", `{"type":"LineString","coordinates":[[100,0],[101,1]]}`, Geometry{Type: GeoJSONLineString, LineString: []Position{{100, 0}, {101, 1}}}},
+			{"MultiLineString", `{"type":"MultiLineString","coordinates":[[[100,0],[101,1]],[[102,2],[103,3]]]}`,
+				Geometry{Type: GeoJSONMultiLineString, MultiLineString: [][]Position{{{100, 0}, {101, 1}}, {{102, 2}, {103, 3}}}}},
+			{"Polygon", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`, Geometry{Type: GeoJSONPolygon, Polygon: [][]Position{square}}},
+			{"MultiPolygon", `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1],[0,0]]]]}`, Geometry{Type: GeoJSONMultiPolygon, MultiPolygon: [][][]Position{{square}}}},
+			{"BBox", `{"type":"Point","coordinates":[1,1],"bbox":[1,1,1,1]}`, Geometry{Type: GeoJSONPoint, Point: Position{1, 1}, BBox: []float64{1, 1, 1, 1}}},
+			{"GeometryCollection", `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,1]}]}`,
+				Geometry{Type: GeoJSONGeometryCollection, Geometries: []Geometry{{Type: GeoJSONPoint, Point: Position{1, 1}}}}},
+			{"EmptyGeometryCollection", `{"type":"GeometryCollection","geometries":[]}`, Geometry{Type: GeoJSONGeometryCollection, Geometries: []Geometry{}}},
+			{"Feature", `{"type":"Feature","id":"f1","geometry":{"type":"Point","coordinates":[1,1]},"properties":{"name":"foo"}}`,
+				Feature{ID: "f1", Geometry: &Geometry{Type: GeoJSONPoint, Point: Position{1, 1}}, Properties: map[string]interface{}{"name": "foo"}}},
+			{"FeatureNullGeometry", `{"type":"Feature","geometry":null,"properties":null}`, Feature{}},
+			{"FeatureCollection", `{"type":"FeatureCollection","features":[{"type":"Feature","id":1,"geometry":null,"properties":{}}]}`,
+				FeatureCollection{Features: []Feature{{ID: float64(1), Properties: map[string]interface{}{}}}}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := decodeGeoJSON(defaultFieldFormat, d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  string
+		}{
+			{"InvalidFormat", "badformat", `{"type":"Point","coordinates":[1,1]}`},
+			{"NotJSON", defaultFieldFormat, `foo`},
+			{"NotObject", defaultFieldFormat, `[1,1]`},
+			{"Null", defaultFieldFormat, `null`},
+			{"UnknownType", defaultFieldFormat, `{"type":"Circle","coordinates":[1,1]}`},
+			{"MissingCoordinates", defaultFieldFormat, `{"type":"Point"}`},
+			{"NonNumericCoordinates", defaultFieldFormat, `{"type":"Point","coordinates":["1","1"]}`},
+			{"ShortPosition", defaultFieldFormat, `{"type":"Point","coordinates":[1]}`},
+			{"WrongNesting", defaultFieldFormat, `{"type":"LineString","coordinates":[1,1]}`},
+			{"ShortLineString", defaultFieldFormat, `{"type":"LineString","coordinates":[[1,1]]}`},
+			{"ShortRing", defaultFieldFormat, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`},
+			{"OpenRing", defaultFieldFormat, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`},
+			{"OpenRingInMultiPolygon", defaultFieldFormat, `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1]]]]}`},
+			{"InvalidBBox", defaultFieldFormat, `{"type":"Point","coordinates":[1,1],"bbox":[1,1,1]}`},
+			{"MissingGeometries", defaultFieldFormat, `{"type":"GeometryCollection"}`},
+			{"InvalidGeometryInCollection", defaultFieldFormat, `{"type":"GeometryCollection","geometries":[{"type":"Point"}]}`},
+			{"FeatureMissingGeometry", defaultFieldFormat, `{"type":"Feature","properties":{}}`},
+			{"FeatureMissingProperties", defaultFieldFormat, `{"type":"Feature","geometry":null}`},
+			{"FeatureInvalidProperties", defaultFieldFormat, `{"type":"Feature","geometry":null,"properties":[1]}`},
+			{"FeatureInvalidID", defaultFieldFormat, `{"type":"Feature","id":true,"geometry":null,"properties":{}}`},
+			{"FeatureInvalidGeometry", defaultFieldFormat, `{"type":"Feature","geometry":{"type":"Point"},"properties":{}}`},
+			{"FeatureCollectionMissingFeatures", defaultFieldFormat, `{"type":"FeatureCollection"}`},
+			{"FeatureCollectionInvalidFeature", defaultFieldFormat, `{"type":"FeatureCollection","features":[{"type":"Point","coordinates":[1,1]}]}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeGeoJSON(d.format, d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestEncodeGeoJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value interface{}
+			want  string
+		}{
+			{"Point", Geometry{Type: GeoJSONPoint, Point: Position{100, 0.5}}, `{"type":"Point","coordinates":[100,0.5]}`},
+			{"Polygon", Geometry{Type: GeoJSONPolygon, Polygon: [][]Position{square}}, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`},
+			{"EmptyGeometryCollection", Geometry{Type: GeoJSONGeometryCollection}, `{"type":"GeometryCollection","geometries":[]}`},
+			{"Feature", Feature{ID: 1, Geometry: &Geometry{Type: GeoJSONPoint, Point: Position{1, 1}}, Properties: map[string]interface{}{"name": "foo"}},
+				`{"type":"Feature","id":1,"geometry":{"type":"Point","coordinates":[1,1]},"properties":{"name":"foo"}}`},
+			{"FeatureNoGeometry", Feature{}, `{"type":"Feature","geometry":null,"properties":null}`},
+			{"EmptyFeatureCollection", FeatureCollection{}, `{"type":"FeatureCollection","features":[]}`},
+			{"StringIsCompacted", `{ "type": "Point", "coordinates": [1, 1], "foo": "bar" }`, `{"type":"Point","coordinates":[1,1]}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := encodeGeoJSON(defaultFieldFormat, d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  interface{}
+		}{
+			{"InvalidType", defaultFieldFormat, 10},
+			{"InvalidFormat", "badformat", Geometry{Type: GeoJSONPoint, Point: Position{1, 1}}},
+			{"InvalidGeometry", defaultFieldFormat, Geometry{Type: GeoJSONPoint}},
+			{"OpenRing", defaultFieldFormat, Geometry{Type: GeoJSONPolygon, Polygon: [][]Position{square[:4]}}},
+			{"InvalidString", defaultFieldFormat, `{"type":"Point"}`},
+			{"TopologyInDefaultFormat", defaultFieldFormat, Topology{Objects: map[string]TopoGeometry{}}},
+			{"GeometryInTopoJSONFormat", GeoJSONTopoJSONFormat, Geometry{Type: GeoJSONPoint, Point: Position{1, 1}}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := encodeGeoJSON(d.format, d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
@@ -17,8 +17,8 @@ const (
 // GeoPoint represents a "geopoint" cell.
 // More at: https://specs.frictionlessdata.io/table-schema/#geopoint
 type GeoPoint struct {
-	Lon float64 `json:"lon,omitempty"`
-	Lat float64 `json:"lat,omitempty"`
+	Lon float64 `json:"lon"`
+	Lat float64 `json:"lat"`
 }
 
 // UnmarshalJSON sets *f to a copy of data. It will respect the default values
@@ -39,65 +39,114 @@ func (p *GeoPoint) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-var (
-	geoPointDefaultRegexp = regexp.MustCompile(`^([-+]?[0-9]*\.?[0-9]*), ?([-+]?[0-9]*\.?[0-9]*)$`)
-	geoPointArrayRegexp   = regexp.MustCompile(`^\[([-+]?[0-9]*\.?[0-9]+), ?([-+]?[0-9]*\.?[0-9]+)\]$`)
-)
+// Validate checks whether the longitude is within [-180, 180] and the latitude is
+// within [-90, 90].
+func (p GeoPoint) Validate() error {
+	if p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("invalid geopoint: longitude %v out of range [-180, 180]", p.Lon)
+	}
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("invalid geopoint: latitude %v out of range [-90, 90]", p.Lat)
+	}
+	return nil
+}
+
+var geoPointDefaultRegexp = regexp.MustCompile(`^([-+]?[0-9]*\.?[0-9]*), ?([-+]?[0-9]*\.?[0-9]*)$`)
+
+// castGeoPoint decodes a geopoint and checks the constraints. Minimum and maximum
+// constraints are written in the field format and define the south-west and the
+// north-east corners of a bounding box, respectively. If the minimum longitude is
+// greater than the maximum, the box crosses the antimeridian.
+func castGeoPoint(format, value string, c Constraints) (GeoPoint, error) {
+	p, err := castGeoPointWithoutChecks(format, value)
+	if err != nil {
+		return p, err
+	}
+	if c.Minimum == "" && c.Maximum == "" {
+		return p, nil
+	}
+	min := GeoPoint{-180, -90}
+	if c.Minimum != "" {
+		if min, err = castGeoPointWithoutChecks(format, c.Minimum); err != nil {
+			return p, fmt.Errorf("invalid minimum geopoint: %v", c.Minimum)
+		}
+	}
+	max := GeoPoint{180, 90}
+	if c.Maximum != "" {
+		if max, err = castGeoPointWithoutChecks(format, c.Maximum); err != nil {
+			return p, fmt.Errorf("invalid maximum geopoint: %v", c.Maximum)
+		}
+	}
+	inLon := p.Lon >= min.Lon && p.Lon <= max.Lon
+	if min.Lon > max.Lon {
+		inLon = p.Lon >= min.Lon || p.Lon <= max.Lon
+	}
+	if !inLon || p.Lat < min.Lat || p.Lat > max.Lat {
+		return p, fmt.Errorf("constraint check error: geopoint:%v out of bounding box minimum:%v maximum:%v", p, min, max)
+	}
+	return p, nil
+}
 
-func castGeoPoint(format, value string) (GeoPoint, error) {
+func castGeoPointWithoutChecks(format, value string) (GeoPoint, error) {
+	var p GeoPoint
 	switch format {
 	case "", defaultFieldFormat:
-		return applyGeoPointRegexp(geoPointDefaultRegexp, value)
+		matches := geoPointDefaultRegexp.FindStringSubmatch(value)
+		if len(matches) == 0 || len(matches[1]) == 0 || len(matches[2]) == 0 {
+			return GeoPoint{}, fmt.Errorf("Invalid geopoint:\"%s\"", value)
+		}
+		lon, err1 := strconv.ParseFloat(matches[1], 64)
+		lat, err2 := strconv.ParseFloat(matches[2], 64)
+		if err1 != nil || err2 != nil {
+			return GeoPoint{}, fmt.Errorf("Invalid geopoint:\"%s\"", value)
+		}
+		p = GeoPoint{lon, lat}
 	case GeoPointArrayFormat:
-		return applyGeoPointRegexp(geoPointArrayRegexp, value)
+		var arr []float64
+		if err := json.Unmarshal([]byte(value), &arr); err != nil || len(arr) != 2 {
+			return GeoPoint{}, fmt.Errorf("Invalid geopoint:\"%s\"", value)
+		}
+		p = GeoPoint{arr[0], arr[1]}
 	case GeoPointObjectFormat:
-		var p GeoPoint
 		if err := json.Unmarshal([]byte(value), &p); err != nil {
 			return GeoPoint{}, err
 		}
-		return p, nil
+	default:
+		return GeoPoint{}, fmt.Errorf("invalid geopoint format:%s", format)
 	}
-	return GeoPoint{}, fmt.Errorf("invalid geopoint format:%s", format)
-}
-
-func applyGeoPointRegexp(r *regexp.Regexp, value string) (GeoPoint, error) {
-	matches := r.FindStringSubmatch(value)
-	if len(matches) == 0 || len(matches[1]) == 0 || len(matches[2]) == 0 {
-		return GeoPoint{}, fmt.Errorf("Invalid geopoint:\"%s\"", value)
-	}
-	lon, _ := strconv.ParseFloat(matches[1], 64)
-	lat, _ := strconv.ParseFloat(matches[2], 64)
-	return GeoPoint{lon, lat}, nil
+	if err := p.Validate(); err != nil {
+		return GeoPoint{}, err
+	}
+	return p, nil
 }
 
 func encodeGeoPoint(format string, gp interface{}) (string, error) {
+	var p GeoPoint
+	switch value := gp.(type) {
+	case GeoPoint:
+		p = value
+	case string:
+		// Strings already in the field format are checked and kept as they are.
+		if _, err := castGeoPointWithoutChecks(format, value); err != nil {
+			return "", err
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid geopoint - type:%v value:\"%v\" format:%s", reflect.TypeOf(gp), gp, format)
+	}
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	lon := strconv.FormatFloat(p.Lon, 'f', -1, 64)
+	lat := strconv.FormatFloat(p.Lat, 'f', -1, 64)
 	switch format {
 	case "", defaultFieldFormat:
-		value, ok := gp.(string)
-		if ok {
-			_, err := applyGeoPointRegexp(geoPointDefaultRegexp, value)
-			if err != nil {
-				return "", err
-			}
-			return value, nil
-		}
-		return "", fmt.Errorf("invalid object type to encode to geopoint dfault format. want:string got:%v", reflect.TypeOf(gp).String())
+		return lon + "," + lat, nil
 	case GeoPointArrayFormat:
-		value, ok := gp.(string)
-		if ok {
-			_, err := applyGeoPointRegexp(geoPointArrayRegexp, value)
-			if err != nil {
-				return "", err
-			}
-			return value, nil
-		}
-		return "", fmt.Errorf("invalid object type to encode to geopoint %s format. want:string got:%v", GeoPointArrayFormat, reflect.TypeOf(gp).String())
+		return "[" + lon + "," + lat + "]", nil
 	case GeoPointObjectFormat:
-		value, ok := gp.(GeoPoint)
-		if ok {
-			return fmt.Sprintf("%+v", value), nil
-		}
-		return "", fmt.Errorf("invalid object type to encode to geopoint %s format. want:schema.Geopoint got:%v", GeoPointObjectFormat, reflect.TypeOf(gp).String())
+		b, err := json.Marshal(p)
+		return string(b), err
 	}
-	return "", fmt.Errorf("invalid geopoint - type:%v value:\"%v\" format:%s", gp, reflect.ValueOf(gp).Type(), format)
+	return "", fmt.Errorf("invalid geopoint format:%s", format)
 }
@@ -29,7 +29,7 @@ func TestCastGeoPoint(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			got, err := castGeoPoint(d.format, d.value)
+			got, err := castGeoPoint(d.format, d.value, noConstraints)
 			is.NoErr(err)
 			is.Equal(got, d.want)
 		})
@@ -45,15 +45,53 @@ func TestCastGeoPoint(t *testing.T) {
 			{"BadFormat", "badformat", `{"longi": 90, "lat": 45}`},
 			{"InvalidDefault", defaultFieldFormat, "/10,10/"},
 			{"InvalidArray", defaultFieldFormat, "/[10,10]/"},
+			{"ArrayTooShort", GeoPointArrayFormat, "[10]"},
+			{"ArrayTooLong", GeoPointArrayFormat, "[10,10,10]"},
+			{"LonOutOfRange", defaultFieldFormat, "180.5,10"},
+			{"LatOutOfRange", defaultFieldFormat, "10,-90.5"},
+			{"ArrayLatOutOfRange", GeoPointArrayFormat, "[10,91]"},
+			{"ObjectLonOutOfRange", GeoPointObjectFormat, `{"lon": -181, "lat": 45}`},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := castGeoPoint(d.format, d.value)
+				_, err := castGeoPoint(d.format, d.value, noConstraints)
 				is.True(err != nil)
 			})
 		}
 	})
+	t.Run("BoundingBox", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+			ok    bool
+		}{
+			{"Inside", "10,10", Constraints{Minimum: "0,0", Maximum: "20,20"}, true},
+			{"OnTheEdge", "20,0", Constraints{Minimum: "0,0", Maximum: "20,20"}, true},
+			{"OnlyMinimum", "10,10", Constraints{Minimum: "0,0"}, true},
+			{"OnlyMaximum", "-10,-10", Constraints{Maximum: "0,0"}, true},
+			{"WestOfBox", "-1,10", Constraints{Minimum: "0,0", Maximum: "20,20"}, false},
+			{"NorthOfBox", "10,21", Constraints{Minimum: "0,0", Maximum: "20,20"}, false},
+			{"CrossingAntimeridianEast", "175,0", Constraints{Minimum: "170,-10", Maximum: "-170,10"}, true},
+			{"CrossingAntimeridianWest", "-175,0", Constraints{Minimum: "170,-10", Maximum: "-170,10"}, true},
+			{"CrossingAntimeridianOutside", "0,0", Constraints{Minimum: "170,-10", Maximum: "-170,10"}, false},
+			{"InvalidMinimum", "10,10", Constraints{Minimum: "foo"}, false},
+			{"InvalidMaximum", "10,10", Constraints{Maximum: "foo"}, false},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castGeoPoint(defaultFieldFormat, d.value, d.c)
+				is.Equal(err == nil, d.ok)
+			})
+		}
+	})
+	t.Run("BoundingBoxArrayFormat", func(t *testing.T) {
+		is := is.New(t)
+		_, err := castGeoPoint(GeoPointArrayFormat, "[10,10]", Constraints{Minimum: "[0,0]", Maximum: "[20,20]"})
+		is.NoErr(err)
+	})
 }
 
 func TestEncodeGeoPoint(t *testing.T) {
@@ -64,7 +102,12 @@ func TestEncodeGeoPoint(t *testing.T) {
 			value  interface{}
 			want   string
 		}{
-			{"GeoPointObject", GeoPointObjectFormat, GeoPoint{10, 10}, "{Lon:10 Lat:10}"},
+			{"GeoPointObject", GeoPointObjectFormat, GeoPoint{10, 10}, `{"lon":10,"lat":10}`},
+			{"GeoPointObjectFloats", GeoPointObjectFormat, GeoPoint{-10.5, 10.25}, `{"lon":-10.5,"lat":10.25}`},
+			{"GeoPointStructArray", GeoPointArrayFormat, GeoPoint{10.5, -20}, "[10.5,-20]"},
+			{"GeoPointStructDefault", defaultFieldFormat, GeoPoint{10.5, -20}, "10.5,-20"},
+			{"GeoPointStructEmptyFormat", "", GeoPoint{0, 0}, "0,0"},
+			{"GeoPointObjectString", GeoPointObjectFormat, `{"lon":10,"lat":10}`, `{"lon":10,"lat":10}`},
 			{"GeoPointArray", GeoPointArrayFormat, "[10,10]", "[10,10]"},
 			{"GeoPointDefault", defaultFieldFormat, "10,10", "10,10"},
 		}
@@ -90,6 +133,10 @@ func TestEncodeGeoPoint(t *testing.T) {
 			{"InvalidObjectType_Default", defaultFieldFormat, int(10)},
 			{"InvalidDefault", defaultFieldFormat, "/10,10/"},
 			{"InvalidFormat", "badFormat", int(10)},
+			{"InvalidFormatStruct", "badFormat", GeoPoint{10, 10}},
+			{"LonOutOfRange", defaultFieldFormat, GeoPoint{190, 10}},
+			{"LatOutOfRange", GeoPointArrayFormat, GeoPoint{10, 95}},
+			{"StringOutOfRange", defaultFieldFormat, "10,95"},
 		}
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/frictionlessdata/tableschema-go/table"
@@ -32,15 +33,18 @@ var (
 		TimeType:      []string{TimeType, StringType},
 		DurationType:  []string{DurationType, StringType},
 		ObjectType:    []string{ObjectType, StringType},
+		GeoJSONType:   []string{GeoJSONType, ObjectType, StringType},
 		ArrayType:     []string{ArrayType, StringType},
+		ListType:      []string{ListType, StringType},
 		GeoPointType:  []string{GeoPointType, ArrayType, StringType},
 		StringType:    []string{},
 	}
 
 	// Types ordered from narrower to wider.
-	orderedTypes = []string{BooleanType, YearType, IntegerType, GeoPointType, NumberType, YearMonthType, DateType, DateTimeType, TimeType, DurationType, ArrayType, ObjectType}
+	orderedTypes = []string{BooleanType, YearType, IntegerType, GeoPointType, NumberType, YearMonthType, DateType, DateTimeType, TimeType, DurationType, ListType, ArrayType, GeoJSONType, ObjectType}
 
-	noConstraints = Constraints{}
+	noConstraints     = Constraints{}
+	noTemporalOptions = temporalOptions{}
 )
 
 // Maximum number of rows used to infer schema.
@@ -50,19 +54,63 @@ const maxNumRowsInfer = 100
 // cells that can inferred as different types, the most popular type is set as the field
 // type. For instance, a column with values 10.1, 10, 10 will inferred as being of type
 // "integer".
-func Infer(tab table.Table) (*Schema, error) {
-	s, err := sample(tab)
+func Infer(tab table.Table, opts ...InferOpts) (*Schema, error) {
+	return InferContext(context.Background(), tab, opts...)
+}
+
+// InferContext is like Infer, but stops reading the table when the context is done,
+// returning the context error.
+func InferContext(ctx context.Context, tab table.Table, opts ...InferOpts) (*Schema, error) {
+	s, err := sample(ctx, tab)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := infer(tab.Headers(), s)
 	if err != nil {
 		return nil, err
 	}
-	return infer(tab.Headers(), s)
+	return applyInferOpts(sch, s, opts)
+}
+
+// InferOpts defines functional options for inferring schemas.
+type InferOpts func(c *inferConfig) error
+
+type inferConfig struct {
+	maxCategories int
+}
+
+// InferCategories makes inference propose categories for string and integer columns
+// which have at most max distinct values, repeated along the sample. If max is not
+// positive, 10 is used.
+func InferCategories(max int) InferOpts {
+	return func(c *inferConfig) error {
+		if max <= 0 {
+			max = defaultMaxInferredCategories
+		}
+		c.maxCategories = max
+		return nil
+	}
+}
+
+func applyInferOpts(sch *Schema, table [][]string, opts []InferOpts) (*Schema, error) {
+	var c inferConfig
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+	if c.maxCategories > 0 {
+		inferCategories(sch, table, c.maxCategories)
+	}
+	return sch, nil
 }
 
-func sample(tab table.Table) ([][]string, error) {
-	iter, err := tab.Iter()
+func sample(ctx context.Context, tab table.Table) ([][]string, error) {
+	iter, err := table.IterContext(ctx, tab)
 	if err != nil {
 		return nil, err
 	}
+	defer iter.Close()
 	var t [][]string
 	for count := 0; count < maxNumRowsInfer && iter.Next(); count++ {
 		t = append(t, iter.Row())
@@ -75,6 +123,7 @@ func sample(tab table.Table) ([][]string, error) {
 
 func infer(headers []string, table [][]string) (*Schema, error) {
 	inferredTypes := make([]map[string]int, len(headers))
+	order := inferenceOrder()
 	for rowID := range table {
 		row := table[rowID]
 		// TODO(danielfireman): the python version does some normalization on
@@ -87,7 +136,7 @@ func infer(headers []string, table [][]string) (*Schema, error) {
 				inferredTypes[cellIndex] = make(map[string]int)
 			}
 			// The list bellow must be ordered by the narrower field type.
-			t := findType(cell, orderedTypes)
+			t := findType(cell, order)
 			inferredTypes[cellIndex][t]++
 		}
 	}
@@ -107,6 +156,9 @@ func infer(headers []string, table [][]string) (*Schema, error) {
 				count = c
 			}
 		}
+		if schema.Fields[index].Type == ListType {
+			inferListField(&schema.Fields[index], column(table, index))
+		}
 	}
 	return &schema, nil
 }
@@ -116,16 +168,27 @@ func infer(headers []string, table [][]string) (*Schema, error) {
 // will inferred as being of type "number" ("integer" can be implicitly cast to "number").
 //
 // For medium to big tables, this method is faster than the Infer.
-func InferImplicitCasting(tab table.Table) (*Schema, error) {
-	s, err := sample(tab)
+func InferImplicitCasting(tab table.Table, opts ...InferOpts) (*Schema, error) {
+	return InferImplicitCastingContext(context.Background(), tab, opts...)
+}
+
+// InferImplicitCastingContext is like InferImplicitCasting, but stops reading the
+// table when the context is done, returning the context error.
+func InferImplicitCastingContext(ctx context.Context, tab table.Table, opts ...InferOpts) (*Schema, error) {
+	s, err := sample(ctx, tab)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := inferImplicitCasting(tab.Headers(), s)
 	if err != nil {
 		return nil, err
 	}
-	return inferImplicitCasting(tab.Headers(), s)
+	return applyInferOpts(sch, s, opts)
 }
 
 func inferImplicitCasting(headers []string, table [][]string) (*Schema, error) {
 	inferredTypes := make([]string, len(headers))
+	order := inferenceOrder()
 	for rowID := range table {
 		row := table[rowID]
 		// TODO(danielfireman): the python version does some normalization on
@@ -135,10 +198,10 @@ func inferImplicitCasting(headers []string, table [][]string) (*Schema, error) {
 		}
 		for cellIndex, cell := range row {
 			if inferredTypes[cellIndex] == "" {
-				t := findType(cell, orderedTypes)
+				t := findType(cell, order)
 				inferredTypes[cellIndex] = t
 			} else {
-				inferredTypes[cellIndex] = findType(cell, implicitCast[inferredTypes[cellIndex]])
+				inferredTypes[cellIndex] = findType(cell, implicitCastOrder(inferredTypes[cellIndex]))
 			}
 		}
 	}
@@ -150,10 +213,21 @@ func inferImplicitCasting(headers []string, table [][]string) (*Schema, error) {
 				Type:   inferredTypes[index],
 				Format: defaultFieldFormat,
 			})
+		if inferredTypes[index] == ListType {
+			inferListField(&schema.Fields[index], column(table, index))
+		}
 	}
 	return &schema, nil
 }
 
+func column(table [][]string, index int) []string {
+	col := make([]string, len(table))
+	for i, row := range table {
+		col[i] = row[index]
+	}
+	return col
+}
+
 func findType(value string, checkOrder []string) string {
 	for _, t := range checkOrder {
 		switch t {
@@ -170,19 +244,19 @@ func findType(value string, checkOrder []string) string {
 				return NumberType
 			}
 		case DateType:
-			if _, err := decodeDate(defaultFieldFormat, value, noConstraints); err == nil {
+			if _, err := decodeDate(defaultFieldFormat, noTemporalOptions, value, noConstraints); err == nil {
 				return DateType
 			}
 		case ArrayType:
-			if _, err := castArray(value); err == nil {
+			if _, err := castArray(value, noConstraints); err == nil {
 				return ArrayType
 			}
 		case ObjectType:
-			if _, err := castObject(value); err == nil {
+			if _, err := castObject(value, noConstraints); err == nil {
 				return ObjectType
 			}
 		case TimeType:
-			if _, err := decodeTime(defaultFieldFormat, value, noConstraints); err == nil {
+			if _, err := decodeTime(defaultFieldFormat, noTemporalOptions, value, noConstraints); err == nil {
 				return TimeType
 			}
 		case YearMonthType:
@@ -194,17 +268,29 @@ func findType(value string, checkOrder []string) string {
 				return YearType
 			}
 		case DateTimeType:
-			if _, err := decodeDateTime(value, noConstraints); err == nil {
+			if _, err := decodeDateTime(defaultFieldFormat, noTemporalOptions, value, noConstraints); err == nil {
 				return DateTimeType
 			}
 		case DurationType:
-			if _, err := castDuration(value); err == nil {
+			if _, err := decodeDuration(value, noConstraints); err == nil {
 				return DurationType
 			}
 		case GeoPointType:
-			if _, err := castGeoPoint(defaultFieldFormat, value); err == nil {
+			if _, err := castGeoPoint(defaultFieldFormat, value, noConstraints); err == nil {
 				return GeoPointType
 			}
+		case ListType:
+			if _, _, ok := inferList(value); ok {
+				return ListType
+			}
+		case GeoJSONType:
+			if _, err := decodeGeoJSON(defaultFieldFormat, value); err == nil {
+				return GeoJSONType
+			}
+		default:
+			if custom, ok := lookupType(t); ok && custom.Infer(value) {
+				return t
+			}
 		}
 	}
 	return StringType
@@ -21,23 +21,8 @@ func castInt(bareNumber bool, value string, c Constraints) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if c.Maximum != "" {
-		max, err := strconv.ParseInt(c.Maximum, 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid maximum integer: %v", c.Maximum)
-		}
-		if returned > max {
-			return 0, fmt.Errorf("constraint check error: integer:%d > maximum:%d", returned, max)
-		}
-	}
-	if c.Minimum != "" {
-		min, err := strconv.ParseInt(c.Minimum, 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid minimum integer: %v", c.Minimum)
-		}
-		if returned < min {
-			return 0, fmt.Errorf("constraint check error: integer:%d < minimum:%d", returned, min)
-		}
+	if err := checkBounds(returned, c, integerOrdering); err != nil {
+		return 0, err
 	}
 	return returned, nil
 }
@@ -0,0 +1,776 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchema is a compiled JSON Schema, used by the jsonSchema constraint. It supports
+// the validation keywords of JSON Schema draft 7 (and the draft 4 boolean form of
+// exclusiveMinimum and exclusiveMaximum), except for format, which is an annotation,
+// and remote references. Only references within the schema itself (e.g. "#/definitions/foo")
+// are resolved.
+// More at: http://json-schema.org/
+type jsonSchema struct {
+	// Set for boolean schemas, which either accept (true) or reject (false) everything.
+	boolean *bool
+
+	types    []string
+	enum     []interface{}
+	hasConst bool
+	constant interface{}
+
+	properties           map[string]*jsonSchema
+	patternProperties    []jsonSchemaPattern
+	additionalProperties *jsonSchema
+	propertyNames        *jsonSchema
+	required             []string
+	minProperties        int
+	maxProperties        int
+	dependencies         map[string]jsonSchemaDependency
+
+	items           *jsonSchema
+	tupleItems      []*jsonSchema
+	additionalItems *jsonSchema
+	contains        *jsonSchema
+	minItems        int
+	maxItems        int
+	uniqueItems     bool
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	minLength int
+	maxLength int
+	pattern   *regexp.Regexp
+
+	allOf []*jsonSchema
+	anyOf []*jsonSchema
+	oneOf []*jsonSchema
+	not   *jsonSchema
+	ifS   *jsonSchema
+	thenS *jsonSchema
+	elseS *jsonSchema
+
+	// Set for references, refSchema being the schema referred by ref.
+	ref       string
+	refSchema *jsonSchema
+}
+
+type jsonSchemaPattern struct {
+	re     *regexp.Regexp
+	schema *jsonSchema
+}
+
+// A dependency is either a list of required properties or a schema.
+type jsonSchemaDependency struct {
+	required []string
+	schema   *jsonSchema
+}
+
+// jsonSchemaCompiler keeps the root document and the compiled references. References
+// are resolved once the schema containing them is compiled, so recursive schemas are
+// supported.
+type jsonSchemaCompiler struct {
+	root       interface{}
+	refs       map[string]*jsonSchema
+	unresolved []*jsonSchema
+}
+
+// errCircularRef is reported when a reference is checked again before any value is
+// read, e.g. {"$ref":"#"}, which would never end the validation.
+var errCircularRef = errors.New("circular $ref")
+
+// compileJSONSchema compiles the passed-in JSON Schema document.
+func compileJSONSchema(data []byte) (*jsonSchema, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid jsonSchema: %v", err)
+	}
+	c := &jsonSchemaCompiler{root: doc, refs: make(map[string]*jsonSchema)}
+	s, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonSchema: %v", err)
+	}
+	// Compiling the referred schemas might add new references.
+	for len(c.unresolved) > 0 {
+		r := c.unresolved[0]
+		c.unresolved = c.unresolved[1:]
+		if r.refSchema, err = c.resolve(r.ref); err != nil {
+			return nil, fmt.Errorf("invalid jsonSchema: %v", err)
+		}
+	}
+	state := make(map[*jsonSchema]int)
+	for _, sub := range s.all(nil, make(map[*jsonSchema]bool)) {
+		if err := sub.checkCycles(state); err != nil {
+			return nil, fmt.Errorf("invalid jsonSchema: %v", err)
+		}
+	}
+	return s, nil
+}
+
+func (c *jsonSchemaCompiler) compile(doc interface{}, path string) (*jsonSchema, error) {
+	s := &jsonSchema{minProperties: -1, maxProperties: -1, minItems: -1, maxItems: -1, minLength: -1, maxLength: -1}
+	switch v := doc.(type) {
+	case bool:
+		s.boolean = &v
+		return s, nil
+	case map[string]interface{}:
+		if ref, ok := v["$ref"]; ok {
+			// As defined by the spec, all other keywords are ignored when there is a reference.
+			r, ok := ref.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/$ref must be a string", path)
+			}
+			if r != "#" && !strings.HasPrefix(r, "#/") {
+				return nil, fmt.Errorf("%s/$ref:\"%s\" only local references are supported", path, r)
+			}
+			s.ref = r
+			c.unresolved = append(c.unresolved, s)
+			return s, nil
+		}
+		return s, s.compileKeywords(c, v, path)
+	}
+	return nil, fmt.Errorf("%s must be an object or a boolean", path)
+}
+
+func (s *jsonSchema) compileKeywords(c *jsonSchemaCompiler, m map[string]interface{}, path string) error {
+	var err error
+	subschema := func(key string) (*jsonSchema, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		return c.compile(v, path+"/"+key)
+	}
+	subschemas := func(key string) ([]*jsonSchema, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		arr, ok := v.([]interface{})
+		if !ok || len(arr) == 0 {
+			return nil, fmt.Errorf("%s/%s must be a non-empty array", path, key)
+		}
+		var ret []*jsonSchema
+		for i, e := range arr {
+			sub, err := c.compile(e, fmt.Sprintf("%s/%s/%d", path, key, i))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, sub)
+		}
+		return ret, nil
+	}
+	number := func(key string) (*float64, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s/%s must be a number", path, key)
+		}
+		return &f, nil
+	}
+	count := func(key string) (int, error) {
+		f, err := number(key)
+		if err != nil || f == nil {
+			return -1, err
+		}
+		if *f < 0 || math.Trunc(*f) != *f {
+			return -1, fmt.Errorf("%s/%s must be a non-negative integer", path, key)
+		}
+		return int(*f), nil
+	}
+	stringList := func(key string) ([]string, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		return toStringList(v, path+"/"+key)
+	}
+
+	if t, ok := m["type"]; ok {
+		switch v := t.(type) {
+		case string:
+			s.types = []string{v}
+		default:
+			if s.types, err = toStringList(t, path+"/type"); err != nil {
+				return err
+			}
+		}
+		for _, t := range s.types {
+			switch t {
+			case "null", "boolean", "object", "array", "number", "integer", "string":
+			default:
+				return fmt.Errorf("%s/type:\"%s\" is not a valid type", path, t)
+			}
+		}
+	}
+	if e, ok := m["enum"]; ok {
+		if s.enum, ok = e.([]interface{}); !ok {
+			return fmt.Errorf("%s/enum must be an array", path)
+		}
+	}
+	s.constant, s.hasConst = m["const"]
+
+	if p, ok := m["properties"]; ok {
+		props, ok := p.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s/properties must be an object", path)
+		}
+		s.properties = make(map[string]*jsonSchema)
+		for name, v := range props {
+			if s.properties[name], err = c.compile(v, path+"/properties/"+name); err != nil {
+				return err
+			}
+		}
+	}
+	if p, ok := m["patternProperties"]; ok {
+		props, ok := p.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s/patternProperties must be an object", path)
+		}
+		for expr, v := range props {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return fmt.Errorf("%s/patternProperties: %v", path, err)
+			}
+			sub, err := c.compile(v, path+"/patternProperties/"+expr)
+			if err != nil {
+				return err
+			}
+			s.patternProperties = append(s.patternProperties, jsonSchemaPattern{re, sub})
+		}
+	}
+	if d, ok := m["dependencies"]; ok {
+		deps, ok := d.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s/dependencies must be an object", path)
+		}
+		s.dependencies = make(map[string]jsonSchemaDependency)
+		for name, v := range deps {
+			var dep jsonSchemaDependency
+			if _, ok := v.([]interface{}); ok {
+				dep.required, err = toStringList(v, path+"/dependencies/"+name)
+			} else {
+				dep.schema, err = c.compile(v, path+"/dependencies/"+name)
+			}
+			if err != nil {
+				return err
+			}
+			s.dependencies[name] = dep
+		}
+	}
+	if s.additionalProperties, err = subschema("additionalProperties"); err != nil {
+		return err
+	}
+	if s.propertyNames, err = subschema("propertyNames"); err != nil {
+		return err
+	}
+	if s.required, err = stringList("required"); err != nil {
+		return err
+	}
+	if s.minProperties, err = count("minProperties"); err != nil {
+		return err
+	}
+	if s.maxProperties, err = count("maxProperties"); err != nil {
+		return err
+	}
+
+	if items, ok := m["items"]; ok {
+		if _, ok := items.([]interface{}); ok {
+			if s.tupleItems, err = subschemas("items"); err != nil {
+				return err
+			}
+		} else if s.items, err = subschema("items"); err != nil {
+			return err
+		}
+	}
+	if s.additionalItems, err = subschema("additionalItems"); err != nil {
+		return err
+	}
+	if s.contains, err = subschema("contains"); err != nil {
+		return err
+	}
+	if s.minItems, err = count("minItems"); err != nil {
+		return err
+	}
+	if s.maxItems, err = count("maxItems"); err != nil {
+		return err
+	}
+	if u, ok := m["uniqueItems"]; ok {
+		if s.uniqueItems, ok = u.(bool); !ok {
+			return fmt.Errorf("%s/uniqueItems must be a boolean", path)
+		}
+	}
+
+	if s.minimum, err = number("minimum"); err != nil {
+		return err
+	}
+	if s.maximum, err = number("maximum"); err != nil {
+		return err
+	}
+	// Draft 4 defines exclusiveMinimum and exclusiveMaximum as booleans changing
+	// the meaning of minimum and maximum.
+	if b, ok := m["exclusiveMinimum"].(bool); ok {
+		if b {
+			s.exclusiveMinimum, s.minimum = s.minimum, nil
+		}
+	} else if s.exclusiveMinimum, err = number("exclusiveMinimum"); err != nil {
+		return err
+	}
+	if b, ok := m["exclusiveMaximum"].(bool); ok {
+		if b {
+			s.exclusiveMaximum, s.maximum = s.maximum, nil
+		}
+	} else if s.exclusiveMaximum, err = number("exclusiveMaximum"); err != nil {
+		return err
+	}
+	if s.multipleOf, err = number("multipleOf"); err != nil {
+		return err
+	}
+	if s.multipleOf != nil && *s.multipleOf <= 0 {
+		return fmt.Errorf("%s/multipleOf must be greater than 0", path)
+	}
+
+	if s.minLength, err = count("minLength"); err != nil {
+		return err
+	}
+	if s.maxLength, err = count("maxLength"); err != nil {
+		return err
+	}
+	if p, ok := m["pattern"]; ok {
+		expr, ok := p.(string)
+		if !ok {
+			return fmt.Errorf("%s/pattern must be a string", path)
+		}
+		if s.pattern, err = regexp.Compile(expr); err != nil {
+			return fmt.Errorf("%s/pattern: %v", path, err)
+		}
+	}
+
+	if s.allOf, err = subschemas("allOf"); err != nil {
+		return err
+	}
+	if s.anyOf, err = subschemas("anyOf"); err != nil {
+		return err
+	}
+	if s.oneOf, err = subschemas("oneOf"); err != nil {
+		return err
+	}
+	if s.not, err = subschema("not"); err != nil {
+		return err
+	}
+	if s.ifS, err = subschema("if"); err != nil {
+		return err
+	}
+	if s.thenS, err = subschema("then"); err != nil {
+		return err
+	}
+	if s.elseS, err = subschema("else"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func toStringList(v interface{}, path string) ([]string, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of strings", path)
+	}
+	ret := make([]string, len(arr))
+	for i, e := range arr {
+		if ret[i], ok = e.(string); !ok {
+			return nil, fmt.Errorf("%s must be an array of strings", path)
+		}
+	}
+	return ret, nil
+}
+
+// resolve returns the schema referred by the JSON pointer ref.
+func (c *jsonSchemaCompiler) resolve(ref string) (*jsonSchema, error) {
+	if s, ok := c.refs[ref]; ok {
+		return s, nil
+	}
+	doc := c.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = v[token]; !ok {
+				return nil, fmt.Errorf("unresolvable $ref:\"%s\"", ref)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("unresolvable $ref:\"%s\"", ref)
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("unresolvable $ref:\"%s\"", ref)
+		}
+	}
+	s, err := c.compile(doc, ref)
+	if err != nil {
+		return nil, err
+	}
+	c.refs[ref] = s
+	return s, nil
+}
+
+// inPlace returns the subschemas which validate the same value as the schema.
+func (s *jsonSchema) inPlace() []*jsonSchema {
+	var ret []*jsonSchema
+	if s.refSchema != nil {
+		ret = append(ret, s.refSchema)
+	}
+	ret = append(ret, s.allOf...)
+	ret = append(ret, s.anyOf...)
+	ret = append(ret, s.oneOf...)
+	for _, sub := range []*jsonSchema{s.not, s.ifS, s.thenS, s.elseS} {
+		if sub != nil {
+			ret = append(ret, sub)
+		}
+	}
+	for _, dep := range s.dependencies {
+		if dep.schema != nil {
+			ret = append(ret, dep.schema)
+		}
+	}
+	return ret
+}
+
+// all appends the schema and all schemas reachable from it to ret.
+func (s *jsonSchema) all(ret []*jsonSchema, seen map[*jsonSchema]bool) []*jsonSchema {
+	if seen[s] {
+		return ret
+	}
+	seen[s] = true
+	ret = append(ret, s)
+	subs := s.inPlace()
+	for _, sub := range s.properties {
+		subs = append(subs, sub)
+	}
+	for _, pp := range s.patternProperties {
+		subs = append(subs, pp.schema)
+	}
+	subs = append(subs, s.tupleItems...)
+	for _, sub := range []*jsonSchema{s.additionalProperties, s.propertyNames, s.items, s.additionalItems, s.contains} {
+		if sub != nil {
+			subs = append(subs, sub)
+		}
+	}
+	for _, sub := range subs {
+		ret = sub.all(ret, seen)
+	}
+	return ret
+}
+
+// checkCycles reports an error if validating a value against the schema could check
+// the schema again against the same value. The state of the visited schemas is 1
+// while their subschemas are checked, and 2 after.
+func (s *jsonSchema) checkCycles(state map[*jsonSchema]int) error {
+	switch state[s] {
+	case 1:
+		return errCircularRef
+	case 2:
+		return nil
+	}
+	state[s] = 1
+	for _, sub := range s.inPlace() {
+		if err := sub.checkCycles(state); err != nil {
+			if err == errCircularRef && s.ref != "" {
+				return fmt.Errorf("$ref:\"%s\" is circular", s.ref)
+			}
+			return err
+		}
+	}
+	state[s] = 2
+	return nil
+}
+
+// validate checks whether v, which must be a value decoded by encoding/json into an
+// interface{}, is valid against the schema.
+func (s *jsonSchema) validate(v interface{}) error {
+	return s.validatePath(v, "")
+}
+
+func (s *jsonSchema) validatePath(v interface{}, path string) error {
+	fail := func(format string, a ...interface{}) error {
+		p := path
+		if p == "" {
+			p = "/"
+		}
+		return fmt.Errorf("jsonSchema check error: %s: %s", p, fmt.Sprintf(format, a...))
+	}
+	if s.boolean != nil {
+		if !*s.boolean {
+			return fail("no value is allowed")
+		}
+		return nil
+	}
+	if s.refSchema != nil {
+		return s.refSchema.validatePath(v, path)
+	}
+
+	if len(s.types) > 0 {
+		found := false
+		for _, t := range s.types {
+			if jsonTypeMatches(t, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("%s is not of type %s", jsonTypeOf(v), strings.Join(s.types, ","))
+		}
+	}
+	if s.enum != nil && !jsonContains(s.enum, v) {
+		return fail("value %v is not one of %v", v, s.enum)
+	}
+	if s.hasConst && !reflect.DeepEqual(s.constant, v) {
+		return fail("value %v is not equal to %v", v, s.constant)
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if err := s.validateObject(value, path, fail); err != nil {
+			return err
+		}
+	case []interface{}:
+		if err := s.validateArray(value, path, fail); err != nil {
+			return err
+		}
+	case float64:
+		if s.minimum != nil && value < *s.minimum {
+			return fail("%v < minimum:%v", value, *s.minimum)
+		}
+		if s.maximum != nil && value > *s.maximum {
+			return fail("%v > maximum:%v", value, *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && value <= *s.exclusiveMinimum {
+			return fail("%v <= exclusiveMinimum:%v", value, *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && value >= *s.exclusiveMaximum {
+			return fail("%v >= exclusiveMaximum:%v", value, *s.exclusiveMaximum)
+		}
+		if s.multipleOf != nil {
+			q := value / *s.multipleOf
+			if math.Abs(q-math.Floor(q+0.5)) > 1e-9 {
+				return fail("%v is not a multiple of %v", value, *s.multipleOf)
+			}
+		}
+	case string:
+		l := utf8.RuneCountInString(value)
+		if s.minLength >= 0 && l < s.minLength {
+			return fail("length %d < minLength:%d", l, s.minLength)
+		}
+		if s.maxLength >= 0 && l > s.maxLength {
+			return fail("length %d > maxLength:%d", l, s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			return fail("\"%s\" does not match pattern %s", value, s.pattern)
+		}
+	}
+
+	for _, sub := range s.allOf {
+		if err := sub.validatePath(v, path); err != nil {
+			return err
+		}
+	}
+	if s.anyOf != nil {
+		found := false
+		for _, sub := range s.anyOf {
+			if sub.validatePath(v, path) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("value does not match any of the anyOf schemas")
+		}
+	}
+	if s.oneOf != nil {
+		matches := 0
+		for _, sub := range s.oneOf {
+			if sub.validatePath(v, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fail("value matches %d of the oneOf schemas, it must match exactly one", matches)
+		}
+	}
+	if s.not != nil && s.not.validatePath(v, path) == nil {
+		return fail("value must not match the not schema")
+	}
+	if s.ifS != nil {
+		if s.ifS.validatePath(v, path) == nil {
+			if s.thenS != nil {
+				return s.thenS.validatePath(v, path)
+			}
+		} else if s.elseS != nil {
+			return s.elseS.validatePath(v, path)
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateObject(obj map[string]interface{}, path string, fail func(string, ...interface{}) error) error {
+	if s.minProperties >= 0 && len(obj) < s.minProperties {
+		return fail("%d properties < minProperties:%d", len(obj), s.minProperties)
+	}
+	if s.maxProperties >= 0 && len(obj) > s.maxProperties {
+		return fail("%d properties > maxProperties:%d", len(obj), s.maxProperties)
+	}
+	for _, r := range s.required {
+		if _, ok := obj[r]; !ok {
+			return fail("missing required property \"%s\"", r)
+		}
+	}
+	// Sorting keys, so errors are deterministic.
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := path + "/" + strings.Replace(strings.Replace(k, "~", "~0", -1), "/", "~1", -1)
+		if s.propertyNames != nil {
+			if err := s.propertyNames.validatePath(k, p); err != nil {
+				return err
+			}
+		}
+		matched := false
+		if sub, ok := s.properties[k]; ok {
+			matched = true
+			if err := sub.validatePath(obj[k], p); err != nil {
+				return err
+			}
+		}
+		for _, pp := range s.patternProperties {
+			if pp.re.MatchString(k) {
+				matched = true
+				if err := pp.schema.validatePath(obj[k], p); err != nil {
+					return err
+				}
+			}
+		}
+		if !matched && s.additionalProperties != nil {
+			if err := s.additionalProperties.validatePath(obj[k], p); err != nil {
+				return err
+			}
+		}
+		if dep, ok := s.dependencies[k]; ok {
+			for _, r := range dep.required {
+				if _, ok := obj[r]; !ok {
+					return fail("property \"%s\" requires property \"%s\"", k, r)
+				}
+			}
+			if dep.schema != nil {
+				if err := dep.schema.validatePath(obj, path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *jsonSchema) validateArray(arr []interface{}, path string, fail func(string, ...interface{}) error) error {
+	if s.minItems >= 0 && len(arr) < s.minItems {
+		return fail("%d items < minItems:%d", len(arr), s.minItems)
+	}
+	if s.maxItems >= 0 && len(arr) > s.maxItems {
+		return fail("%d items > maxItems:%d", len(arr), s.maxItems)
+	}
+	if s.uniqueItems {
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if reflect.DeepEqual(arr[i], arr[j]) {
+					return fail("items %d and %d are equal, items must be unique", i, j)
+				}
+			}
+		}
+	}
+	for i, e := range arr {
+		p := fmt.Sprintf("%s/%d", path, i)
+		var sub *jsonSchema
+		switch {
+		case s.items != nil:
+			sub = s.items
+		case i < len(s.tupleItems):
+			sub = s.tupleItems[i]
+		case s.tupleItems != nil:
+			sub = s.additionalItems
+		}
+		if sub != nil {
+			if err := sub.validatePath(e, p); err != nil {
+				return err
+			}
+		}
+	}
+	if s.contains != nil {
+		found := false
+		for i, e := range arr {
+			if s.contains.validatePath(e, fmt.Sprintf("%s/%d", path, i)) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail("no item matches the contains schema")
+		}
+	}
+	return nil
+}
+
+func jsonTypeMatches(t string, v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	case string:
+		return t == "string"
+	case float64:
+		return t == "number" || (t == "integer" && math.Trunc(value) == value)
+	}
+	return false
+}
+
+func jsonTypeOf(v interface{}) string {
+	for _, t := range []string{"null", "boolean", "object", "array", "string", "integer", "number"} {
+		if jsonTypeMatches(t, v) {
+			return t
+		}
+	}
+	return reflect.TypeOf(v).String()
+}
+
+func jsonContains(values []interface{}, v interface{}) bool {
+	for _, e := range values {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
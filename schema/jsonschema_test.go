@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestJSONSchema(t *testing.T) {
+	data := []struct {
+		desc   string
+		schema string
+		valid  []string
+		inval  []string
+	}{
+		{"BooleanTrue", `true`, []string{`1`, `{}`}, nil},
+		{"BooleanFalse", `false`, nil, []string{`1`, `{}`}},
+		{"Type", `{"type":"integer"}`, []string{`1`, `1.0`}, []string{`1.5`, `"1"`, `null`}},
+		{"Types", `{"type":["string","null"]}`, []string{`"a"`, `null`}, []string{`1`, `[]`}},
+		{"Enum", `{"enum":[1,"a",[1]]}`, []string{`1`, `"a"`, `[1]`}, []string{`2`, `["a"]`}},
+		{"Const", `{"const":{"a":1}}`, []string{`{"a":1}`}, []string{`{"a":2}`}},
+		{"Properties", `{"properties":{"a":{"type":"string"}},"required":["a"],"additionalProperties":false}`,
+			[]string{`{"a":"foo"}`}, []string{`{"a":1}`, `{}`, `{"a":"foo","b":1}`}},
+		{"PatternProperties", `{"patternProperties":{"^x-":{"type":"integer"}},"additionalProperties":{"type":"string"}}`,
+			[]string{`{"x-a":1,"b":"c"}`}, []string{`{"x-a":"1"}`, `{"b":1}`}},
+		{"PropertyCount", `{"minProperties":1,"maxProperties":2}`, []string{`{"a":1}`, `[]`}, []string{`{}`, `{"a":1,"b":2,"c":3}`}},
+		{"PropertyNames", `{"propertyNames":{"maxLength":2}}`, []string{`{"ab":1}`}, []string{`{"abc":1}`}},
+		{"Dependencies", `{"dependencies":{"a":["b"],"c":{"required":["d"]}}}`,
+			[]string{`{"a":1,"b":2}`, `{"b":1}`, `{"c":1,"d":2}`}, []string{`{"a":1}`, `{"c":1}`}},
+		{"Items", `{"items":{"type":"number"},"minItems":1,"maxItems":2,"uniqueItems":true}`,
+			[]string{`[1]`, `[1,2]`}, []string{`[]`, `[1,2,3]`, `["a"]`, `[1,1]`}},
+		{"TupleItems", `{"items":[{"type":"string"},{"type":"number"}],"additionalItems":false}`,
+			[]string{`["a",1]`, `["a"]`}, []string{`[1,"a"]`, `["a",1,2]`}},
+		{"Contains", `{"contains":{"const":1}}`, []string{`[2,1]`}, []string{`[2]`, `[]`}},
+		{"Numbers", `{"minimum":1,"exclusiveMaximum":3,"multipleOf":0.5}`, []string{`1`, `2.5`}, []string{`0.5`, `3`, `1.25`}},
+		{"Draft4ExclusiveMinimum", `{"minimum":1,"exclusiveMinimum":true}`, []string{`1.5`}, []string{`1`}},
+		{"Strings", `{"minLength":2,"maxLength":3,"pattern":"^a"}`, []string{`"ab"`, `"aéé"`}, []string{`"a"`, `"abcd"`, `"ba"`}},
+		{"AllOf", `{"allOf":[{"type":"number"},{"minimum":1}]}`, []string{`1`}, []string{`0`, `"a"`}},
+		{"AnyOf", `{"anyOf":[{"type":"string"},{"minimum":1}]}`, []string{`"a"`, `2`}, []string{`0`}},
+		{"OneOf", `{"oneOf":[{"type":"integer"},{"minimum":1}]}`, []string{`0`, `1.5`}, []string{`2`, `0.5`}},
+		{"Not", `{"not":{"type":"string"}}`, []string{`1`}, []string{`"a"`}},
+		{"IfThenElse", `{"if":{"type":"string"},"then":{"minLength":2},"else":{"minimum":1}}`, []string{`"ab"`, `1`}, []string{`"a"`, `0`}},
+		{"Ref", `{"definitions":{"pos":{"type":"integer","minimum":0}},"properties":{"a":{"$ref":"#/definitions/pos"}}}`,
+			[]string{`{"a":1}`}, []string{`{"a":-1}`}},
+		{"RecursiveRef", `{"type":"object","properties":{"child":{"$ref":"#"}},"additionalProperties":false}`,
+			[]string{`{"child":{"child":{}}}`}, []string{`{"child":{"foo":1}}`}},
+		{"RefToRef", `{"definitions":{"a":{"$ref":"#/definitions/b"},"b":{"type":"string"}},"allOf":[{"$ref":"#/definitions/a"}]}`,
+			[]string{`"a"`}, []string{`1`}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			s, err := compileJSONSchema([]byte(d.schema))
+			is.NoErr(err)
+			for _, v := range d.valid {
+				var value interface{}
+				is.NoErr(json.Unmarshal([]byte(v), &value))
+				is.NoErr(s.validate(value)) // valid value
+			}
+			for _, v := range d.inval {
+				var value interface{}
+				is.NoErr(json.Unmarshal([]byte(v), &value))
+				is.True(s.validate(value) != nil) // invalid value
+			}
+		})
+	}
+	t.Run("InvalidSchema", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			schema string
+		}{
+			{"NotJSON", `{`},
+			{"NotObject", `1`},
+			{"InvalidType", `{"type":"foo"}`},
+			{"InvalidPattern", `{"pattern":"("}`},
+			{"InvalidMinLength", `{"minLength":-1}`},
+			{"InvalidMultipleOf", `{"multipleOf":0}`},
+			{"EmptyAllOf", `{"allOf":[]}`},
+			{"RemoteRef", `{"$ref":"http://example.com/schema"}`},
+			{"InvalidSubschema", `{"properties":{"a":1}}`},
+			{"UnresolvableRef", `{"properties":{"a":{"$ref":"#/definitions/foo"}}}`},
+			{"InvalidRefSchema", `{"definitions":{"a":{"type":1}},"properties":{"a":{"$ref":"#/definitions/a"}}}`},
+			{"CircularRef", `{"definitions":{"a":{"$ref":"#/definitions/a"}},"$ref":"#/definitions/a"}`},
+			{"IndirectCircularRef", `{"definitions":{"a":{"$ref":"#/definitions/b"},"b":{"$ref":"#/definitions/a"}},"$ref":"#/definitions/a"}`},
+			{"CircularAllOf", `{"allOf":[{"$ref":"#"}]}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := compileJSONSchema([]byte(d.schema))
+				is.True(err != nil)
+			})
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Defaults for list fields.
+const (
+	defaultListDelimiter = ","
+	defaultListItemType  = StringType
+)
+
+// Go types of the decoded list items, by item type.
+// More at: https://datapackage.org/standard/table-schema/#list
+var listItemTypes = map[string]reflect.Type{
+	StringType:   reflect.TypeOf(""),
+	IntegerType:  reflect.TypeOf(int64(0)),
+	NumberType:   reflect.TypeOf(float64(0)),
+	BooleanType:  reflect.TypeOf(false),
+	DateType:     timeTimeType,
+	DateTimeType: timeTimeType,
+	TimeType:     reflect.TypeOf(TimeOfDay{}),
+}
+
+// Delimiters tried when inferring lists.
+var listInferDelimiters = []string{",", ";"}
+
+// listItemField returns the field used to decode and encode the items of the
+// passed-in list field.
+func listItemField(f *Field) (*Field, reflect.Type, error) {
+	itemType := f.ItemType
+	if itemType == "" {
+		itemType = defaultListItemType
+	}
+	goType, ok := listItemTypes[itemType]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid list item type:%s", itemType)
+	}
+	item := *f
+	item.Type = itemType
+	item.Constraints = Constraints{}
+	// Missing values apply to the whole cell, items must be valid.
+	item.MissingValues, item.MissingValueLabels = nil, nil
+	return &item, goType, nil
+}
+
+func listDelimiter(f *Field) string {
+	if f.Delimiter == "" {
+		return defaultListDelimiter
+	}
+	return f.Delimiter
+}
+
+// decodeList splits the cell using the field delimiter and decodes every item
+// using the field item type. It returns a slice of the item Go type, for
+// instance []int64 for integer items.
+func decodeList(f *Field, value string) (interface{}, error) {
+	item, goType, err := listItemField(f)
+	if err != nil {
+		return nil, err
+	}
+	var items []string
+	if value != "" {
+		items = strings.Split(value, listDelimiter(f))
+	}
+	c := f.Constraints
+	if c.MinLength != 0 && len(items) < c.MinLength {
+		return nil, fmt.Errorf("constraint check error: list length %v < minimum:%v", len(items), c.MinLength)
+	}
+	if c.MaxLength != 0 && len(items) > c.MaxLength {
+		return nil, fmt.Errorf("constraint check error: list length %v > maximum:%v", len(items), c.MaxLength)
+	}
+	ret := reflect.MakeSlice(reflect.SliceOf(goType), len(items), len(items))
+	for i, s := range items {
+		v, err := item.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid list item %d:%v", i, err)
+		}
+		ret.Index(i).Set(reflect.ValueOf(v))
+	}
+	return ret.Interface(), nil
+}
+
+// encodeList encodes every element of the passed-in slice (or array) using the
+// field item type and joins them using the field delimiter. Strings are checked
+// and returned as they are.
+func encodeList(f *Field, in interface{}) (string, error) {
+	if s, ok := in.(string); ok {
+		if _, err := decodeList(f, s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", fmt.Errorf("invalid list - value:%v type:%v", in, reflect.TypeOf(in))
+	}
+	item, _, err := listItemField(f)
+	if err != nil {
+		return "", err
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		if items[i], err = item.Encode(v.Index(i).Interface()); err != nil {
+			return "", fmt.Errorf("invalid list item %d:%v", i, err)
+		}
+	}
+	return strings.Join(items, listDelimiter(f)), nil
+}
+
+// Item types tried when inferring lists, from narrower to wider. Lists of strings
+// are not inferred, as any text containing a delimiter would be a list.
+var listInferItemTypes = []string{BooleanType, IntegerType, NumberType, DateType, DateTimeType, TimeType}
+
+// inferList reports whether the value is a list of at least two non-string items,
+// returning the delimiter and item type.
+func inferList(value string) (string, string, bool) {
+	for _, d := range listInferDelimiters {
+		items := strings.Split(value, d)
+		if len(items) < 2 {
+			continue
+		}
+		t := inferListItems("", items)
+		if t != StringType {
+			return d, t, true
+		}
+	}
+	return "", "", false
+}
+
+// inferListItems returns the narrowest item type which can hold all items, starting
+// from the passed-in item type (empty if unknown).
+func inferListItems(t string, items []string) string {
+	for _, i := range items {
+		if t == "" {
+			t = findType(i, listInferItemTypes)
+		} else {
+			t = findType(i, implicitCastOrder(t))
+		}
+		if _, ok := listItemTypes[t]; !ok {
+			t = StringType
+		}
+	}
+	return t
+}
+
+// inferListField sets the delimiter and item type of an inferred list field, based on
+// the column cells.
+func inferListField(f *Field, column []string) {
+	for _, cell := range column {
+		if d, _, ok := inferList(cell); ok {
+			f.Delimiter = d
+			break
+		}
+	}
+	t := ""
+	for _, cell := range column {
+		if cell == "" {
+			continue
+		}
+		t = inferListItems(t, strings.Split(cell, f.Delimiter))
+	}
+	f.ItemType = t
+}
+
+// convertList converts a decoded list into the passed-in slice type, if the
+// elements are convertible (e.g. []int64 into []int).
+func convertList(v reflect.Value, t reflect.Type) (interface{}, bool) {
+	if t.Kind() != reflect.Slice || !v.Type().Elem().ConvertibleTo(t.Elem()) {
+		return nil, false
+	}
+	ret := reflect.MakeSlice(t, v.Len(), v.Len())
+	for i := 0; i < v.Len(); i++ {
+		ret.Index(i).Set(v.Index(i).Convert(t.Elem()))
+	}
+	return ret.Interface(), true
+}
@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeList(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+			want  interface{}
+		}{
+			{"Strings", Field{Type: ListType}, "red,green,blue", []string{"red", "green", "blue"}},
+			{"Delimiter", Field{Type: ListType, Delimiter: ";"}, "red;green;blue", []string{"red", "green", "blue"}},
+			{"Empty", Field{Type: ListType}, "", []string{}},
+			{"Integers", Field{Type: ListType, ItemType: IntegerType, Delimiter: ";"}, "1;2;3", []int64{1, 2, 3}},
+			{"Numbers", Field{Type: ListType, ItemType: NumberType, Delimiter: ";", DecimalChar: "."}, "1.5;2", []float64{1.5, 2}},
+			{"Booleans", Field{Type: ListType, ItemType: BooleanType, TrueValues: defaultTrueValues, FalseValues: defaultFalseValues}, "true,no", []bool{true, false}},
+			{"Dates", Field{Type: ListType, ItemType: DateType}, "2017-01-02,2018-03-04",
+				[]time.Time{time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2018, 3, 4, 0, 0, 0, 0, time.UTC)}},
+			{"Times", Field{Type: ListType, ItemType: TimeType}, "10:00:00,11:30:00", []TimeOfDay{{Hour: 10}, {Hour: 11, Minute: 30}}},
+			{"Length", Field{Type: ListType, Constraints: Constraints{MinLength: 2, MaxLength: 2}}, "a,b", []string{"a", "b"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := d.field.Decode(d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value string
+		}{
+			{"InvalidItem", Field{Type: ListType, ItemType: IntegerType}, "1,a"},
+			{"InvalidItemType", Field{Type: ListType, ItemType: ObjectType}, "{}"},
+			{"MinLength", Field{Type: ListType, Constraints: Constraints{MinLength: 3}}, "a,b"},
+			{"MaxLength", Field{Type: ListType, Constraints: Constraints{MaxLength: 1}}, "a,b"},
+			{"MissingItem", Field{Type: ListType, ItemType: IntegerType, MissingValues: missingValuesSet([]string{"", "NA"})}, "1,NA,2"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := d.field.Decode(d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestEncodeList(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value interface{}
+			want  string
+		}{
+			{"Strings", Field{Type: ListType}, []string{"red", "green"}, "red,green"},
+			{"Delimiter", Field{Type: ListType, Delimiter: ";"}, []string{"red", "green"}, "red;green"},
+			{"Integers", Field{Type: ListType, ItemType: IntegerType}, []int{1, 2}, "1,2"},
+			{"Array", Field{Type: ListType, ItemType: IntegerType}, [2]int64{1, 2}, "1,2"},
+			{"Dates", Field{Type: ListType, ItemType: DateType, Format: "%Y-%m-%d"}, []time.Time{time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)}, "2017-01-02"},
+			{"String", Field{Type: ListType, ItemType: IntegerType}, "1,2", "1,2"},
+			{"Empty", Field{Type: ListType}, []string{}, ""},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := d.field.Encode(d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			field Field
+			value interface{}
+		}{
+			{"NotSlice", Field{Type: ListType}, 10},
+			{"InvalidItem", Field{Type: ListType, ItemType: IntegerType}, []string{"a"}},
+			{"InvalidString", Field{Type: ListType, ItemType: IntegerType}, "1,a"},
+			{"InvalidItemType", Field{Type: ListType, ItemType: ObjectType}, []string{"a"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := d.field.Encode(d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestInferList(t *testing.T) {
+	data := []struct {
+		desc      string
+		column    []string
+		delimiter string
+		itemType  string
+	}{
+		{"Booleans", []string{"true,false", "false,true"}, ",", BooleanType},
+		{"Integers", []string{"1;2;3", "4;5"}, ";", IntegerType},
+		{"IntegersAndNumbers", []string{"1;2", "4.5;5"}, ";", NumberType},
+		{"Dates", []string{"2017-01-02,2017-01-03"}, ",", DateType},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			rows := make([][]string, len(d.column))
+			for i, c := range d.column {
+				rows[i] = []string{c}
+			}
+			for _, inferFunc := range []func([]string, [][]string) (*Schema, error){infer, inferImplicitCasting} {
+				s, err := inferFunc([]string{"list"}, rows)
+				is.NoErr(err)
+				is.Equal(s.Fields[0].Type, ListType)
+				is.Equal(s.Fields[0].Delimiter, d.delimiter)
+				is.Equal(s.Fields[0].ItemType, d.itemType)
+			}
+		})
+	}
+	t.Run("SkipsEmptyCells", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Type: ListType}
+		inferListField(&f, []string{"", "1;2", ""})
+		is.Equal(f.Delimiter, ";")
+		is.Equal(f.ItemType, IntegerType)
+	})
+	t.Run("NotList", func(t *testing.T) {
+		is := is.New(t)
+		s, err := infer([]string{"text"}, [][]string{{"Hello, world"}})
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, StringType)
+	})
+}
+
+func TestSchemaDecode_List(t *testing.T) {
+	is := is.New(t)
+	s := Schema{Fields: []Field{{Name: "Values", Type: ListType, ItemType: IntegerType, Delimiter: ";"}}}
+	var row struct{ Values []int }
+	is.NoErr(s.Decode([]string{"1;2;3"}, &row))
+	is.Equal(row.Values, []int{1, 2, 3})
+}
+
+func TestSchemaDecode_ListMissingItems(t *testing.T) {
+	is := is.New(t)
+	s, err := Read(strings.NewReader(`{"fields": [{"name": "Values", "type": "list", "itemType": "integer"}], "missingValues": ["", "NA"]}`))
+	is.NoErr(err)
+	var row struct{ Values []int64 }
+	is.True(s.Decode([]string{"1,NA,2"}, &row) != nil) // missing values only apply to whole cells
+	is.NoErr(s.Decode([]string{"1,2"}, &row))
+	is.Equal(row.Values, []int64{1, 2})
+}
@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MissingValue is returned by Field.Decode for cells holding one of the field missing
+// values. It is the decoded null, carrying which missing value applied and its label
+// (if any), so a "refused" answer can be told apart from a "not asked" one.
+// More at: https://datapackage.org/standard/table-schema/#missingvalues
+type MissingValue struct {
+	Value string
+	Label string
+}
+
+// IsMissing reports whether the passed-in decoded value is a missing value.
+func IsMissing(v interface{}) bool {
+	_, ok := v.(MissingValue)
+	return ok
+}
+
+// parseMissingValues processes the missingValues property, which is either a list of
+// strings or a list of objects with value and label.
+func parseMissingValues(ph interface{}) ([]string, map[string]string, error) {
+	list, ok := ph.([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("missingValues must be a list")
+	}
+	values := make([]string, 0, len(list))
+	var labels map[string]string
+	for _, e := range list {
+		switch v := e.(type) {
+		case string:
+			values = append(values, v)
+		case map[string]interface{}:
+			value, ok := v["value"].(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("missingValues value must be a string")
+			}
+			label, ok := v["label"].(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("missingValues label of %s must be a string", value)
+			}
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			values = append(values, value)
+			labels[value] = label
+		default:
+			return nil, nil, fmt.Errorf("missingValues must contain strings or objects with value and label")
+		}
+	}
+	return values, labels, nil
+}
+
+// missingValuesPlaceholder creates the missingValues property. The object form is
+// only used if there are labels.
+func missingValuesPlaceholder(values []string, labels map[string]string) interface{} {
+	if len(labels) == 0 {
+		return values
+	}
+	list := make([]interface{}, len(values))
+	for i, v := range values {
+		list[i] = map[string]string{"value": v, "label": labels[v]}
+	}
+	return list
+}
+
+func missingValuesSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+func sortedMissingValues(set map[string]struct{}) []string {
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// missingValue returns the missing value the passed-in cell corresponds to.
+func (f *Field) missingValue(value string) (MissingValue, bool) {
+	if _, ok := f.MissingValues[value]; !ok {
+		return MissingValue{}, false
+	}
+	return MissingValue{Value: value, Label: f.MissingValueLabels[value]}, true
+}
+
+// inheritMissingValues sets the schema missing values to the field, unless the
+// field defines its own.
+func (f *Field) inheritMissingValues(values []string, labels map[string]string) {
+	if f.MissingValues != nil || len(values) == 0 {
+		return
+	}
+	f.MissingValues = missingValuesSet(values)
+	f.MissingValueLabels = labels
+	f.inheritedMissingValues = true
+}
@@ -0,0 +1,107 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestMissingValues_Read(t *testing.T) {
+	t.Run("FieldOverridesSchema", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Read(strings.NewReader(`{"fields":[{"name":"a","type":"integer"},{"name":"b","type":"integer","missingValues":["-1"]},{"name":"c","type":"string","missingValues":[]}],"missingValues":["na"]}`))
+		is.NoErr(err)
+		_, ok := s.Fields[0].MissingValues["na"]
+		is.True(ok)
+		is.Equal(s.Fields[1].MissingValues, map[string]struct{}{"-1": {}})
+		is.Equal(len(s.Fields[2].MissingValues), 0)
+
+		v, err := s.Fields[2].Decode("na")
+		is.NoErr(err)
+		is.Equal(v, "na")
+		_, err = s.Fields[1].Decode("na")
+		is.True(err != nil)
+	})
+	t.Run("Labels", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Read(strings.NewReader(`{"fields":[{"name":"age","type":"integer"}],"missingValues":[{"value":"-99","label":"refused"},{"value":"-98","label":"not asked"}]}`))
+		is.NoErr(err)
+		is.Equal(s.MissingValues, []string{"-99", "-98"})
+		v, err := s.Fields[0].Decode("-99")
+		is.NoErr(err)
+		is.Equal(v, MissingValue{Value: "-99", Label: "refused"})
+		is.True(IsMissing(v))
+		v, err = s.Fields[0].Decode("-98")
+		is.NoErr(err)
+		is.Equal(v, MissingValue{Value: "-98", Label: "not asked"})
+		v, err = s.Fields[0].Decode("42")
+		is.NoErr(err)
+		is.Equal(v, int64(42))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		data := []string{
+			`{"fields":[{"name":"a"}],"missingValues":"na"}`,
+			`{"fields":[{"name":"a"}],"missingValues":[1]}`,
+			`{"fields":[{"name":"a"}],"missingValues":[{"value":"-99"}]}`,
+			`{"fields":[{"name":"a","missingValues":[{"label":"refused"}]}]}`,
+		}
+		for _, d := range data {
+			is := is.New(t)
+			_, err := Read(strings.NewReader(d))
+			is.True(err != nil)
+		}
+	})
+}
+
+func TestMissingValues_Write(t *testing.T) {
+	is := is.New(t)
+	in := `{"fields":[{"name":"a","type":"integer"},{"name":"b","type":"integer","missingValues":[{"value":"-1","label":"unknown"}]}],"missingValues":["","na"]}`
+	s, err := Read(strings.NewReader(in))
+	is.NoErr(err)
+	buf, err := json.Marshal(s)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(buf), `{"name":"a","type":"integer","missingValues"`))
+	is.True(strings.Contains(string(buf), `"missingValues":[{"label":"unknown","value":"-1"}]`))
+	is.True(strings.Contains(string(buf), `"missingValues":["","na"]`))
+
+	s2, err := Read(strings.NewReader(string(buf)))
+	is.NoErr(err)
+	is.Equal(s, s2)
+}
+
+func TestMissingValues_Field(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "age", Type: IntegerType, Constraints: Constraints{Required: true}, MissingValues: map[string]struct{}{"-99": {}}}
+		_, err := f.Decode("-99")
+		is.True(err != nil)
+	})
+	t.Run("Encode", func(t *testing.T) {
+		is := is.New(t)
+		f := Field{Name: "age", Type: IntegerType, MissingValues: map[string]struct{}{"-99": {}}}
+		v, err := f.Encode(MissingValue{Value: "-99", Label: "refused"})
+		is.NoErr(err)
+		is.Equal(v, "-99")
+	})
+}
+
+func TestMissingValues_Decode(t *testing.T) {
+	is := is.New(t)
+	s, err := Read(strings.NewReader(`{"fields":[{"name":"Age","type":"integer"},{"name":"Answer","type":"integer"}],"missingValues":[{"value":"-99","label":"refused"}]}`))
+	is.NoErr(err)
+	row := struct {
+		Age    int
+		Answer interface{}
+	}{}
+	is.NoErr(s.Decode([]string{"-99", "-99"}, &row))
+	is.Equal(row.Age, 0)
+	is.Equal(row.Answer, MissingValue{Value: "-99", Label: "refused"})
+
+	// Schema missing values apply to fields created without Read.
+	s = &Schema{Fields: []Field{{Name: "Age", Type: IntegerType}, {Name: "Answer", Type: IntegerType}}, MissingValues: []string{"na"}}
+	is.NoErr(s.Decode([]string{"na", "1"}, &row))
+	is.Equal(row.Age, 0)
+	is.Equal(row.Answer, int64(1))
+}
@@ -29,23 +29,8 @@ func castNumber(decimalChar, groupChar string, bareNumber bool, value string, c
 	if err != nil {
 		return 0, err
 	}
-	if c.Maximum != "" {
-		max, err := strconv.ParseFloat(c.Maximum, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid maximum number: %v", c.Maximum)
-		}
-		if returned > max {
-			return 0, fmt.Errorf("constraint check error: integer:%f > maximum:%f", returned, max)
-		}
-	}
-	if c.Minimum != "" {
-		min, err := strconv.ParseFloat(c.Minimum, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid minimum integer: %v", c.Minimum)
-		}
-		if returned < min {
-			return 0, fmt.Errorf("constraint check error: integer:%f > minimum:%f", returned, min)
-		}
+	if err := checkBounds(returned, c, numberOrdering); err != nil {
+		return 0, err
 	}
 	return returned, nil
 }
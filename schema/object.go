@@ -1,16 +1,82 @@
 package schema
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
 
-func castObject(value string) (interface{}, error) {
+func castObject(value string, c Constraints) (interface{}, error) {
 	var obj interface{}
 	if err := json.Unmarshal([]byte(value), &obj); err != nil {
 		return nil, err
 	}
-	return obj, nil
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not an JSON object", value)
+	}
+	if err := checkJSONConstraints(m, len(m), c); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func encodeObject(value interface{}) (string, error) {
 	b, err := json.Marshal(value)
 	return string(b), err
 }
+
+// checkJSONConstraints checks the constraints of array and object values. The length
+// is the number of elements of arrays and the number of keys of objects.
+func checkJSONConstraints(v interface{}, length int, c Constraints) error {
+	if c.MinLength != 0 && length < c.MinLength {
+		return fmt.Errorf("constraint check error: length %v < minimum:%v", length, c.MinLength)
+	}
+	if c.MaxLength != 0 && length > c.MaxLength {
+		return fmt.Errorf("constraint check error: length %v > maximum:%v", length, c.MaxLength)
+	}
+	if len(c.Enum) > 0 {
+		found := false
+		for _, e := range c.Enum {
+			ev, err := normalizeJSONValue(e)
+			if err != nil {
+				return fmt.Errorf("invalid enum value %v: %v", e, err)
+			}
+			if reflect.DeepEqual(ev, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("constraint check error: %v not in enum:%v", v, c.Enum)
+		}
+	}
+	s := c.compiledJSONSchema
+	if s == nil && len(c.JSONSchema) > 0 {
+		var err error
+		if s, err = compileJSONSchema(c.JSONSchema); err != nil {
+			return err
+		}
+	}
+	if s != nil {
+		return s.validate(v)
+	}
+	return nil
+}
+
+// normalizeJSONValue returns v as it would be decoded by encoding/json into an
+// interface{}. Strings are decoded as JSON.
+func normalizeJSONValue(v interface{}) (interface{}, error) {
+	var b []byte
+	if s, ok := v.(string); ok {
+		b = []byte(s)
+	} else {
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	var ret interface{}
+	err := json.Unmarshal(b, &ret)
+	return ret, err
+}
@@ -24,3 +24,91 @@ func TestEncodeObject(t *testing.T) {
 		})
 	}
 }
+
+func TestCastObject(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+		}{
+			{"NoConstraints", `{"a":1}`, Constraints{}},
+			{"Length", `{"a":1,"b":2}`, Constraints{MinLength: 2, MaxLength: 2}},
+			{"EnumJSONValue", `{"a":1}`, Constraints{Enum: []interface{}{map[string]interface{}{"a": 1}}}},
+			{"EnumString", `{"a":1}`, Constraints{Enum: []interface{}{`{"b":1}`, `{"a":1}`}}},
+			{"JSONSchema", `{"a":1}`, Constraints{JSONSchema: []byte(`{"required":["a"]}`)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castObject(d.value, d.c)
+				is.NoErr(err)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+		}{
+			{"NotJSON", `{"a"}`, Constraints{}},
+			{"NotObject", `[1]`, Constraints{}},
+			{"MinLength", `{"a":1}`, Constraints{MinLength: 2}},
+			{"MaxLength", `{"a":1,"b":2}`, Constraints{MaxLength: 1}},
+			{"Enum", `{"a":1}`, Constraints{Enum: []interface{}{`{"a":2}`}}},
+			{"InvalidEnum", `{"a":1}`, Constraints{Enum: []interface{}{`{`}}},
+			{"JSONSchema", `{"a":1}`, Constraints{JSONSchema: []byte(`{"required":["b"]}`)}},
+			{"InvalidJSONSchema", `{"a":1}`, Constraints{JSONSchema: []byte(`{"type":1}`)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castObject(d.value, d.c)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestCastArray(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+		}{
+			{"NoConstraints", `[1]`, Constraints{}},
+			{"Length", `[1,2]`, Constraints{MinLength: 1, MaxLength: 2}},
+			{"Enum", `["a"]`, Constraints{Enum: []interface{}{[]string{"a"}}}},
+			{"JSONSchema", `[1,2]`, Constraints{JSONSchema: []byte(`{"items":{"type":"integer"}}`)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castArray(d.value, d.c)
+				is.NoErr(err)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+		}{
+			{"NotArray", `{"a":1}`, Constraints{}},
+			{"MinLength", `[1]`, Constraints{MinLength: 2}},
+			{"MaxLength", `[1,2]`, Constraints{MaxLength: 1}},
+			{"Enum", `["b"]`, Constraints{Enum: []interface{}{[]string{"a"}}}},
+			{"JSONSchema", `[1,"a"]`, Constraints{JSONSchema: []byte(`{"items":{"type":"integer"}}`)}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := castArray(d.value, d.c)
+				is.True(err != nil)
+			})
+		}
+	})
+}
@@ -1,16 +1,16 @@
 package schema
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"reflect"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/frictionlessdata/tableschema-go/remote"
 	"github.com/frictionlessdata/tableschema-go/table"
 )
 
@@ -21,39 +21,34 @@ const InvalidPosition = -1
 // Unexportet tagname for the tableheader
 const tableheaderTag = "tableheader"
 
+var (
+	timeDurationType = reflect.TypeOf(time.Duration(0))
+	timeTimeType     = reflect.TypeOf(time.Time{})
+	missingValueType = reflect.TypeOf(MissingValue{})
+)
+
 // Read reads and parses a descriptor to create a schema.
 //
 // Example - Reading a schema from a file:
 //
-//  f, err := os.Open("foo/bar/schema.json")
-//  if err != nil {
-//    panic(err)
-//  }
-//  s, err := Read(f)
-//  if err != nil {
-//    panic(err)
-//  }
-//  fmt.Println(s)
+//	f, err := os.Open("foo/bar/schema.json")
+//	if err != nil {
+//	  panic(err)
+//	}
+//	s, err := Read(f)
+//	if err != nil {
+//	  panic(err)
+//	}
+//	fmt.Println(s)
 func Read(r io.Reader) (*Schema, error) {
 	var s Schema
 	dec := json.NewDecoder(r)
 	if err := dec.Decode(&s); err != nil {
 		return nil, err
 	}
-	if len(s.MissingValues) == 0 {
-		return &s, nil
-	}
-	// Transforming the list in a set.
-	valueSet := make(map[string]struct{}, len(s.MissingValues))
-	for _, v := range s.MissingValues {
-		valueSet[v] = struct{}{}
-	}
-	// Updating fields.
+	// Updating fields which do not define their own missing values.
 	for i := range s.Fields {
-		s.Fields[i].MissingValues = make(map[string]struct{}, len(valueSet))
-		for k, v := range valueSet {
-			s.Fields[i].MissingValues[k] = v
-		}
+		s.Fields[i].inheritMissingValues(s.MissingValues, s.MissingValueLabels)
 	}
 	return &s, nil
 }
@@ -67,26 +62,20 @@ func LoadFromFile(path string) (*Schema, error) {
 	return Read(f)
 }
 
-var (
-	httpClient *http.Client
-	once       sync.Once
-)
-
-const remoteFetchTimeoutSecs = 15
-
 // LoadRemote downloads and parses a schema descriptor from the specified URL.
 func LoadRemote(url string) (*Schema, error) {
-	once.Do(func() {
-		httpClient = &http.Client{
-			Timeout: remoteFetchTimeoutSecs * time.Second,
-		}
-	})
-	resp, err := httpClient.Get(url)
+	return LoadRemoteWithOptions(url, remote.Options{})
+}
+
+// LoadRemoteWithOptions downloads and parses a schema descriptor from the specified URL,
+// configuring the request (e.g. context, headers and retries) with the options.
+func LoadRemoteWithOptions(url string, o remote.Options) (*Schema, error) {
+	body, err := remote.Get(url, o)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	return Read(resp.Body)
+	defer body.Close()
+	return Read(body)
 }
 
 // Fields represents a list of schema fields.
@@ -116,7 +105,17 @@ type Schema struct {
 	PrimaryKeyPlaceholder interface{} `json:"primaryKey,omitempty"`
 	PrimaryKeys           []string    `json:"-"`
 	ForeignKeys           ForeignKeys `json:"foreignKeys,omitempty"`
-	MissingValues         []string    `json:"missingValues,omitempty"`
+	MissingValues         []string    `json:"-"`
+	// MissingValueLabels maps missing values to their labels, e.g. "-99" to "refused".
+	MissingValueLabels       map[string]string `json:"-"`
+	MissingValuesPlaceholder interface{}       `json:"missingValues,omitempty"`
+
+	// Location is used to interpret datetime and time values which do not have an UTC
+	// offset, for fields which do not set their own Field.Location. Defaults to UTC.
+	Location *time.Location `json:"-"`
+	// Clock returns the current time for fields which do not set their own Field.Clock.
+	// Defaults to time.Now.
+	Clock func() time.Time `json:"-"`
 }
 
 // GetField fetches the index and field referenced by the name argument.
@@ -144,6 +143,9 @@ func (s *Schema) Validate() error {
 		if f.Name == "" {
 			return fmt.Errorf("invalid field: attribute name is mandatory")
 		}
+		if f.Type != "" && !isKnownType(f.Type) {
+			return fmt.Errorf("invalid field %s: unknown type %s", f.Name, f.Type)
+		}
 	}
 	// Checking primary keys.
 	for _, pk := range s.PrimaryKeys {
@@ -190,6 +192,12 @@ func (s *Schema) SaveToFile(path string) error {
 // this call will return an error. Furthermore, this call is also going to return an error if
 // the schema field value can not be unmarshalled to the struct field type.
 func (s *Schema) Decode(row []string, out interface{}) error {
+	return s.decode(row, out, func(i int) *Field { return s.withDefaults(&s.Fields[i]) })
+}
+
+// decode decodes the row using schemaField, which returns the schema field of the
+// given index with the schema-wide defaults.
+func (s *Schema) decode(row []string, out interface{}, schemaField func(int) *Field) error {
 	if reflect.ValueOf(out).Kind() != reflect.Ptr || reflect.Indirect(reflect.ValueOf(out)).Kind() != reflect.Struct {
 		return fmt.Errorf("can only decode pointer to structs")
 	}
@@ -203,14 +211,21 @@ func (s *Schema) Decode(row []string, out interface{}) error {
 			if !ok { // if no tag is set use own name
 				fieldName = field.Name
 			}
-			f, fieldIndex := s.GetField(fieldName)
+			_, fieldIndex := s.GetField(fieldName)
 			if fieldIndex != InvalidPosition {
 				cell := row[fieldIndex]
-				if s.isMissingValue(cell) {
+				v, err := schemaField(fieldIndex).Decode(cell)
+				if err != nil {
+					return err
+				}
+				// Missing values are left unset, unless the struct field can hold them.
+				if mv, ok := v.(MissingValue); ok {
+					if missingValueType.AssignableTo(field.Type) {
+						fieldValue.Set(reflect.ValueOf(mv))
+					}
 					continue
 				}
-				v, err := f.Decode(cell)
-				if err != nil {
+				if v, err = convertToStructField(v, field.Type); err != nil {
 					return err
 				}
 				toSetValue := reflect.ValueOf(v)
@@ -252,13 +267,65 @@ func (s *Schema) Encode(in interface{}) ([]string, error) {
 	return row, nil
 }
 
-func (s *Schema) isMissingValue(value string) bool {
-	for _, mv := range s.MissingValues {
-		if mv == value {
-			return true
+// convertToStructField converts decoded values to the standard library types
+// used in struct fields, keeping compatibility with previous versions.
+func convertToStructField(v interface{}, t reflect.Type) (interface{}, error) {
+	switch value := v.(type) {
+	case Duration:
+		if t == timeDurationType {
+			return value.ToDuration()
 		}
+	case Year:
+		if t == timeTimeType {
+			return value.Start(time.UTC), nil
+		}
+	case YearMonth:
+		if t == timeTimeType {
+			return value.Start(time.UTC), nil
+		}
+	case TimeOfDay:
+		if t == timeTimeType {
+			return value.On(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)), nil
+		}
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.Type() != t {
+		if l, ok := convertList(rv, t); ok {
+			return l, nil
+		}
+	}
+	return v, nil
+}
+
+// withDefaults returns the passed-in field with unset properties filled with the
+// schema-wide values.
+func (s *Schema) withDefaults(f *Field) *Field {
+	missing := f.MissingValues == nil && len(s.MissingValues) > 0
+	location := f.Location == nil && s.Location != nil
+	clock := f.Clock == nil && s.Clock != nil
+	if !missing && !location && !clock {
+		return f
+	}
+	c := *f
+	if location {
+		c.Location = s.Location
+	}
+	if clock {
+		c.Clock = s.Clock
+	}
+	c.inheritMissingValues(s.MissingValues, s.MissingValueLabels)
+	return &c
+}
+
+// preparedFields returns copies of the schema fields with the schema-wide defaults,
+// whose constraints are parsed once, to decode all cells of a table.
+func (s *Schema) preparedFields() []*Field {
+	fields := make([]*Field, len(s.Fields))
+	for i := range s.Fields {
+		f := *s.withDefaults(&s.Fields[i])
+		f.prepare()
+		fields[i] = &f
 	}
-	return false
+	return fields
 }
 
 // UnmarshalJSON sets *f to a copy of data. It will respect the default values
@@ -282,6 +349,14 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("foreignKeys.reference.fields must be either a string or list")
 	}
 	a.ForeignKeys.Reference.FieldsPlaceholder = nil
+	if a.MissingValuesPlaceholder != nil {
+		values, labels, err := parseMissingValues(a.MissingValuesPlaceholder)
+		if err != nil {
+			return err
+		}
+		a.MissingValues, a.MissingValueLabels = values, labels
+		a.MissingValuesPlaceholder = nil
+	}
 	*s = Schema(a)
 	return nil
 }
@@ -292,6 +367,9 @@ func (s *Schema) MarshalJSON() ([]byte, error) {
 	a := schemaAlias(*s)
 	a.PrimaryKeyPlaceholder = a.PrimaryKeys
 	a.ForeignKeys.Reference.FieldsPlaceholder = a.ForeignKeys.Reference.Fields
+	if a.MissingValues != nil {
+		a.MissingValuesPlaceholder = missingValuesPlaceholder(a.MissingValues, a.MissingValueLabels)
+	}
 	return json.Marshal(a)
 }
 
@@ -319,11 +397,17 @@ func processPlaceholder(ph interface{}, v *[]string) error {
 // The result argument must necessarily be the address for a slice. The slice
 // may be nil or previously allocated.
 func (s *Schema) DecodeTable(tab table.Table, out interface{}) error {
+	return s.DecodeTableContext(context.Background(), tab, out)
+}
+
+// DecodeTableContext is like DecodeTable, but stops when the context is done,
+// returning the context error.
+func (s *Schema) DecodeTableContext(ctx context.Context, tab table.Table, out interface{}) error {
 	outv := reflect.ValueOf(out)
 	if outv.Kind() != reflect.Ptr || outv.Elem().Kind() != reflect.Slice {
 		return fmt.Errorf("out argument must be a slice address")
 	}
-	iter, err := tab.Iter()
+	iter, err := table.IterContext(ctx, tab)
 	if err != nil {
 		return err
 	}
@@ -331,10 +415,12 @@ func (s *Schema) DecodeTable(tab table.Table, out interface{}) error {
 	slicev := outv.Elem()
 	slicev = slicev.Slice(0, 0) // Trucantes the passed-in slice.
 	elemt := slicev.Type().Elem()
+	fields := s.preparedFields()
+	field := func(i int) *Field { return fields[i] }
 	i := 0
 	for iter.Next() {
 		elemp := reflect.New(elemt)
-		if err := s.Decode(iter.Row(), elemp.Interface()); err != nil {
+		if err := s.decode(iter.Row(), elemp.Interface(), field); err != nil {
 			return err
 		}
 		slicev = reflect.Append(slicev, elemp.Elem())
@@ -348,6 +434,35 @@ func (s *Schema) DecodeTable(tab table.Table, out interface{}) error {
 	return nil
 }
 
+// ValidateTable checks all table rows have a cell for each schema field which
+// can be decoded, i.e. it has the field type and follows its constraints.
+func (s *Schema) ValidateTable(tab table.Table) error {
+	return s.ValidateTableContext(context.Background(), tab)
+}
+
+// ValidateTableContext is like ValidateTable, but stops when the context is done,
+// returning the context error.
+func (s *Schema) ValidateTableContext(ctx context.Context, tab table.Table) error {
+	iter, err := table.IterContext(ctx, tab)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	fields := s.preparedFields()
+	for row := 1; iter.Next(); row++ {
+		cells := iter.Row()
+		if len(cells) != len(fields) {
+			return fmt.Errorf("row %d: %d cells, schema has %d fields", row, len(cells), len(fields))
+		}
+		for i, f := range fields {
+			if _, err := f.Decode(cells[i]); err != nil {
+				return fmt.Errorf("row %d: field %s: %v", row, f.Name, err)
+			}
+		}
+	}
+	return iter.Err()
+}
+
 // EncodeTable encodes each element (struct) of the passed-in slice and
 func (s *Schema) EncodeTable(in interface{}) ([][]string, error) {
 	inVal := reflect.Indirect(reflect.ValueOf(in))
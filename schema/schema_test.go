@@ -2,12 +2,15 @@ package schema
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/frictionlessdata/tableschema-go/remote"
 	"github.com/frictionlessdata/tableschema-go/table"
 	"github.com/matryer/is"
 )
@@ -136,6 +139,31 @@ func TestLoadRemote(t *testing.T) {
 		_, err := LoadRemote("invalidURL")
 		is.True(err != nil)
 	})
+	t.Run("NotFound", func(t *testing.T) {
+		is := is.New(t)
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		_, err := LoadRemote(ts.URL)
+		statusErr, ok := err.(*remote.StatusError)
+		is.True(ok)
+		is.Equal(statusErr.StatusCode, http.StatusNotFound)
+	})
+}
+
+func TestLoadRemoteWithOptions(t *testing.T) {
+	is := is.New(t)
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"fields": [{"name": "ID", "type": "integer"}]}`)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(h))
+	defer ts.Close()
+	got, err := LoadRemoteWithOptions(ts.URL, remote.Options{Header: http.Header{"Authorization": {"Bearer token"}}})
+	is.NoErr(err)
+	is.Equal(len(got.Fields), 1)
 }
 
 func TestRead_Sucess(t *testing.T) {
@@ -267,6 +295,81 @@ func TestSchema_Decode(t *testing.T) {
 		is.NoErr(s.Decode([]string{"Foo", "42"}, &t1))
 		is.Equal(t1.Age, 42)
 	})
+	t.Run("DurationToTimeDuration", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct {
+			D  Duration
+			TD time.Duration
+		}{}
+		s := Schema{Fields: []Field{{Name: "D", Type: DurationType}, {Name: "TD", Type: DurationType}}}
+		is.NoErr(s.Decode([]string{"P1M", "PT1H30M"}, &t1))
+		is.Equal(t1.D, Duration{Months: 1})
+		is.Equal(t1.TD, 90*time.Minute)
+	})
+	t.Run("EnumInSchemaLocation", func(t *testing.T) {
+		is := is.New(t)
+		s, err := Read(strings.NewReader(`{"fields":[{"name":"T","type":"datetime","constraints":{"enum":["2017-12-31T13:45:00"]}}]}`))
+		is.NoErr(err)
+		s.Location = time.FixedZone("UTC+2", 2*3600)
+		t1 := struct{ T time.Time }{}
+		for i := 0; i < 2; i++ {
+			is.NoErr(s.Decode([]string{"2017-12-31T13:45:00"}, &t1))
+			is.True(t1.T.Equal(time.Date(2017, 12, 31, 11, 45, 0, 0, time.UTC)))
+		}
+		is.Equal(len(s.Fields[0].Constraints.enum.values), 2) // enum parsed once for each location
+	})
+	t.Run("TimeOfDay", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct {
+			TD TimeOfDay
+			T  time.Time
+		}{}
+		s := Schema{Fields: []Field{{Name: "TD", Type: TimeType}, {Name: "T", Type: TimeType}}}
+		is.NoErr(s.Decode([]string{"13:45:00", "13:45:00"}, &t1))
+		is.Equal(t1.TD, TimeOfDay{Hour: 13, Minute: 45})
+		is.Equal(t1.T, time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC))
+	})
+	t.Run("YearAndYearMonth", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct {
+			Y     Year
+			YInt  int
+			YM    YearMonth
+			YTime time.Time
+		}{}
+		s := Schema{Fields: []Field{{Name: "Y", Type: YearType}, {Name: "YInt", Type: YearType}, {Name: "YM", Type: YearMonthType}, {Name: "YTime", Type: YearMonthType}}}
+		is.NoErr(s.Decode([]string{"2017", "2018", "2017-05", "2017-06"}, &t1))
+		is.Equal(t1.Y, Year(2017))
+		is.Equal(t1.YInt, 2018)
+		is.Equal(t1.YM, YearMonth{2017, time.May})
+		is.Equal(t1.YTime, time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		row, err := s.Encode(t1)
+		is.NoErr(err)
+		is.Equal(row, []string{"2017", "2018", "2017-05", "2017-06"})
+	})
+	t.Run("Error_CalendarDurationToTimeDuration", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct{ TD time.Duration }{}
+		s := Schema{Fields: []Field{{Name: "TD", Type: DurationType}}}
+		is.True(s.Decode([]string{"P1M"}, &t1) != nil)
+	})
+	t.Run("Location", func(t *testing.T) {
+		is := is.New(t)
+		t1 := struct{ Schema, Field time.Time }{}
+		loc := time.FixedZone("", 3600)
+		s := Schema{
+			Fields: []Field{
+				{Name: "Schema", Type: DateTimeType},
+				{Name: "Field", Type: DateTimeType, Location: time.FixedZone("", -3600)},
+			},
+			Location: loc,
+		}
+		is.NoErr(s.Decode([]string{"2017-12-31T13:45:00", "2017-12-31T13:45:00"}, &t1))
+		is.Equal(t1.Schema, time.Date(2017, 12, 31, 12, 45, 0, 0, time.UTC))
+		is.Equal(t1.Field, time.Date(2017, 12, 31, 14, 45, 0, 0, time.UTC))
+		is.True(s.Fields[0].Location == nil) // schema fields must not be changed
+	})
 	t.Run("Error_SchemaFieldAndStructFieldDifferentTypes", func(t *testing.T) {
 		is := is.New(t)
 		// Field is string and struct is int.
@@ -323,6 +426,7 @@ func TestValidate_Invalid(t *testing.T) {
 		Schema Schema
 	}{
 		{"MissingName", Schema{Fields: []Field{{Type: IntegerType}}}},
+		{"UnknownType", Schema{Fields: []Field{{Name: "n1", Type: "ipaddress"}}}},
 		{"PKNonexistingField", Schema{Fields: []Field{{Name: "n1"}}, PrimaryKeys: []string{"n2"}}},
 		{"FKNonexistingField", Schema{Fields: []Field{{Name: "n1"}},
 			ForeignKeys: ForeignKeys{Fields: []string{"n2"}},
@@ -486,6 +590,68 @@ func TestDecodeTable(t *testing.T) {
 	})
 }
 
+func TestDecodeTableContext(t *testing.T) {
+	is := is.New(t)
+	tab := table.FromSlices([]string{"Name"}, [][]string{{"foo"}, {"bar"}})
+	s := &Schema{Fields: []Field{{Name: "Name", Type: StringType}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var got []csvRow
+	is.Equal(s.DecodeTableContext(ctx, tab, &got), context.Canceled)
+}
+
+func TestValidateTable(t *testing.T) {
+	s := &Schema{Fields: []Field{
+		{Name: "Name", Type: StringType, Constraints: Constraints{Required: true}},
+		{Name: "Age", Type: IntegerType},
+	}, MissingValues: []string{""}}
+	data := []struct {
+		desc string
+		rows [][]string
+		err  string
+	}{
+		{"Valid", [][]string{{"foo", "42"}, {"bar", ""}}, ""},
+		{"InvalidType", [][]string{{"foo", "42"}, {"bar", "old"}}, "row 2: field Age:"},
+		{"Required", [][]string{{"", "42"}}, "row 1: field Name:"},
+		{"MissingCell", [][]string{{"foo"}}, "row 1: 1 cells, schema has 2 fields"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			err := s.ValidateTable(table.FromSlices([]string{"Name", "Age"}, d.rows))
+			if d.err == "" {
+				is.NoErr(err)
+				return
+			}
+			is.True(err != nil)
+			is.True(strings.HasPrefix(err.Error(), d.err))
+		})
+	}
+	t.Run("Canceled", func(t *testing.T) {
+		is := is.New(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := s.ValidateTableContext(ctx, table.FromSlices([]string{"Name", "Age"}, [][]string{{"foo", "42"}}))
+		is.Equal(err, context.Canceled)
+	})
+}
+
+func TestPreparedFields(t *testing.T) {
+	is := is.New(t)
+	loc := time.FixedZone("UTC+2", 2*3600)
+	s := &Schema{Fields: []Field{
+		{Name: "Age", Type: IntegerType, Constraints: Constraints{Maximum: "150", Enum: []interface{}{"42"}}},
+		{Name: "Time", Type: DateTimeType},
+	}, MissingValues: []string{""}, Location: loc}
+	fields := s.preparedFields()
+	is.True(fields[0].Constraints.bounds != nil)        // bounds must be parsed once
+	is.Equal(len(fields[0].Constraints.enum.values), 1) // enum must be parsed once
+	is.True(fields[0].MissingValues != nil)             // schema missing values
+	is.Equal(fields[1].Location, loc)
+	is.True(s.Fields[0].Constraints.bounds == nil) // the schema must not be modified
+	is.True(s.Fields[1].Location == nil)
+}
+
 func TestSchema_Encode(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		is := is.New(t)
@@ -0,0 +1,567 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Custom date/time formats follow the Python strftime/strptime syntax, as described at
+// https://specs.frictionlessdata.io/table-schema/#date and
+// https://docs.python.org/3/library/datetime.html#strftime-and-strptime-behavior
+//
+// Besides the directives documented by Python, the following (widely used) C library
+// extensions are also supported: %e, %k, %l, %s, %h, %n, %t, the composites %D, %F, %R,
+// %r and %T, and the padding flags "-" (no padding), "_" (space padding) and "0" (zero
+// padding). Any other directive results in an error.
+
+// strftimeToken is either a literal (verb == 0) or a directive.
+type strftimeToken struct {
+	literal string
+	verb    byte
+	flag    byte
+}
+
+// Composite directives and their expansion in the C/POSIX locale.
+var strftimeComposites = map[byte]string{
+	'c': "%a %b %e %H:%M:%S %Y",
+	'x': "%m/%d/%y",
+	'X': "%H:%M:%S",
+	'D': "%m/%d/%y",
+	'F': "%Y-%m-%d",
+	'R': "%H:%M",
+	'r': "%I:%M:%S %p",
+	'T': "%H:%M:%S",
+}
+
+// Numeric directives and their maximum width.
+var strftimeNumericWidth = map[byte]int{
+	'd': 2,
+	'e': 2,
+	'm': 2,
+	'y': 2,
+	'Y': 4,
+	'H': 2,
+	'k': 2,
+	'I': 2,
+	'l': 2,
+	'M': 2,
+	'S': 2,
+	'f': 6,
+	'j': 3,
+	'U': 2,
+	'W': 2,
+	'w': 1,
+	'u': 1,
+	'G': 4,
+	'V': 2,
+}
+
+// Directives padded with spaces by default.
+var strftimeSpacePadded = map[byte]bool{'e': true, 'k': true, 'l': true}
+
+// tokenizeStrftime splits a strftime format into literals and directives. Composite
+// directives are expanded.
+func tokenizeStrftime(format string) ([]strftimeToken, error) {
+	var tokens []strftimeToken
+	var lit bytes.Buffer
+	flushLiteral := func() {
+		if lit.Len() > 0 {
+			tokens = append(tokens, strftimeToken{literal: lit.String()})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			lit.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i >= len(format) {
+			return nil, fmt.Errorf("invalid strftime format:\"%s\" ends with an incomplete directive", format)
+		}
+		var flag byte
+		switch format[i] {
+		case '-', '_', '0', ':':
+			flag = format[i]
+			i++
+			if i >= len(format) {
+				return nil, fmt.Errorf("invalid strftime format:\"%s\" ends with an incomplete directive", format)
+			}
+		}
+		verb := format[i]
+		if flag == ':' && verb != 'z' {
+			return nil, fmt.Errorf("unsupported strftime directive:%%:%c", verb)
+		}
+		switch verb {
+		case '%':
+			lit.WriteByte('%')
+			continue
+		case 'n':
+			lit.WriteByte('\n')
+			continue
+		case 't':
+			lit.WriteByte('\t')
+			continue
+		}
+		if exp, ok := strftimeComposites[verb]; ok {
+			flushLiteral()
+			expTokens, _ := tokenizeStrftime(exp)
+			tokens = append(tokens, expTokens...)
+			continue
+		}
+		if _, ok := strftimeNumericWidth[verb]; !ok && !strings.ContainsRune("aAbBhpzZs", rune(verb)) {
+			return nil, fmt.Errorf("unsupported strftime directive:%%%c", verb)
+		}
+		flushLiteral()
+		tokens = append(tokens, strftimeToken{verb: verb, flag: flag})
+	}
+	flushLiteral()
+	return tokens, nil
+}
+
+// strptimeResult holds the components found while parsing.
+type strptimeResult struct {
+	year, month, day, hour, minute, second, nanos int
+	pm, hasAMPM                                   bool
+	yday                                          int
+	weekday                                       int // 0-6, Sunday is 0.
+	hasWeekday                                    bool
+	weekNumber                                    int
+	weekNumberVerb                                byte
+	isoYear, isoWeek                              int
+	hasMonthOrDay                                 bool
+	loc                                           *time.Location
+	zone                                          string // Zone name of loc, e.g. "EST".
+	unix                                          *int64
+}
+
+// strptime parses value according to the passed-in strftime format. If the format
+// does not contain any time zone information, the value is interpreted in loc.
+func strptime(format, value string, loc *time.Location) (time.Time, error) {
+	tokens, err := tokenizeStrftime(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	r := strptimeResult{month: 1, day: 1, loc: loc}
+	v := value
+	for _, tok := range tokens {
+		if tok.verb == 0 {
+			v, err = consumeStrftimeLiteral(tok.literal, v)
+		} else {
+			v, err = r.consume(tok, v)
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("value:\"%s\" does not match format \"%s\": %v", value, format, err)
+		}
+	}
+	if v != "" {
+		return time.Time{}, fmt.Errorf("value:\"%s\" does not match format \"%s\": unconverted data remains:\"%s\"", value, format, v)
+	}
+	return r.time(value)
+}
+
+// consumeStrftimeLiteral consumes a literal from the value. Like Python, any whitespace
+// in the format matches one or more whitespaces in the value and matching is case-insensitive.
+func consumeStrftimeLiteral(lit, v string) (string, error) {
+	for len(lit) > 0 {
+		if isSpace(lit[0]) {
+			lit = strings.TrimLeftFunc(lit, unicode.IsSpace)
+			if len(v) == 0 || !isSpace(v[0]) {
+				return v, fmt.Errorf("expected whitespace")
+			}
+			v = strings.TrimLeftFunc(v, unicode.IsSpace)
+			continue
+		}
+		if len(v) == 0 || !strings.EqualFold(lit[:1], v[:1]) {
+			return v, fmt.Errorf("expected \"%s\"", lit)
+		}
+		lit, v = lit[1:], v[1:]
+	}
+	return v, nil
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
+
+func (r *strptimeResult) consume(tok strftimeToken, v string) (string, error) {
+	switch tok.verb {
+	case 'a', 'A':
+		i, rest, err := consumeName(v, 7, func(i int) string { return time.Weekday(i).String() }, tok.verb == 'a')
+		if err != nil {
+			return v, err
+		}
+		r.weekday, r.hasWeekday = i, true
+		return rest, nil
+	case 'b', 'h', 'B':
+		i, rest, err := consumeName(v, 12, func(i int) string { return time.Month(i + 1).String() }, tok.verb != 'B')
+		if err != nil {
+			return v, err
+		}
+		r.month, r.hasMonthOrDay = i+1, true
+		return rest, nil
+	case 'p':
+		if len(v) < 2 {
+			return v, fmt.Errorf("expected AM or PM")
+		}
+		switch strings.ToUpper(v[:2]) {
+		case "AM":
+			r.pm = false
+		case "PM":
+			r.pm = true
+		default:
+			return v, fmt.Errorf("expected AM or PM")
+		}
+		r.hasAMPM = true
+		return v[2:], nil
+	case 'z':
+		return r.consumeOffset(v, tok.flag == ':')
+	case 'Z':
+		// Zones without name are formatted as offsets.
+		if len(v) > 0 && (v[0] == '+' || v[0] == '-') {
+			return r.consumeOffset(v, false)
+		}
+		end := strings.IndexFunc(v, func(c rune) bool { return !unicode.IsLetter(c) })
+		if end == -1 {
+			end = len(v)
+		}
+		switch strings.ToUpper(v[:end]) {
+		case "":
+			return v, fmt.Errorf("expected time zone name")
+		case "UTC", "GMT", "Z":
+			r.loc = time.UTC
+		default:
+			// Other names must be used by the location, which is checked once the
+			// time is known, e.g. "EST" and "EDT" for America/New_York.
+			r.zone = v[:end]
+		}
+		return v[end:], nil
+	case 's':
+		end := 0
+		if end < len(v) && (v[0] == '-' || v[0] == '+') {
+			end++
+		}
+		for end < len(v) && v[end] >= '0' && v[end] <= '9' {
+			end++
+		}
+		s, err := strconv.ParseInt(v[:end], 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("expected seconds since epoch")
+		}
+		r.unix = &s
+		return v[end:], nil
+	}
+	return r.consumeNumber(tok, v)
+}
+
+func (r *strptimeResult) consumeNumber(tok strftimeToken, v string) (string, error) {
+	if strftimeSpacePadded[tok.verb] || tok.flag == '_' {
+		v = strings.TrimLeft(v, " ")
+	}
+	width := strftimeNumericWidth[tok.verb]
+	minWidth := 1
+	if tok.verb == 'Y' && tok.flag == 0 {
+		minWidth = 4
+	}
+	end := 0
+	for end < len(v) && end < width && v[end] >= '0' && v[end] <= '9' {
+		end++
+	}
+	if end < minWidth {
+		return v, fmt.Errorf("expected %d digits for %%%c", minWidth, tok.verb)
+	}
+	n, _ := strconv.Atoi(v[:end])
+	digits := v[:end]
+	v = v[end:]
+	checkRange := func(min, max int) error {
+		if n < min || n > max {
+			return fmt.Errorf("%%%c out of range:%d", tok.verb, n)
+		}
+		return nil
+	}
+	var err error
+	switch tok.verb {
+	case 'd', 'e':
+		r.day, r.hasMonthOrDay = n, true
+		err = checkRange(1, 31)
+	case 'm':
+		r.month, r.hasMonthOrDay = n, true
+		err = checkRange(1, 12)
+	case 'y':
+		// Following POSIX: 69-99 are in the 20th century and 00-68 in the 21st.
+		if n < 69 {
+			r.year = n + 2000
+		} else {
+			r.year = n + 1900
+		}
+	case 'Y':
+		r.year = n
+	case 'H', 'k':
+		r.hour = n
+		err = checkRange(0, 23)
+	case 'I', 'l':
+		r.hour = n
+		err = checkRange(1, 12)
+	case 'M':
+		r.minute = n
+		err = checkRange(0, 59)
+	case 'S':
+		r.second = n
+		err = checkRange(0, 59)
+	case 'f':
+		// Fractions are right-padded: "5" means 500000 microseconds.
+		micros, _ := strconv.Atoi(digits + strings.Repeat("0", 6-len(digits)))
+		r.nanos = micros * 1000
+	case 'j':
+		r.yday = n
+		err = checkRange(1, 366)
+	case 'U', 'W':
+		r.weekNumber, r.weekNumberVerb = n, tok.verb
+		err = checkRange(0, 53)
+	case 'w':
+		r.weekday, r.hasWeekday = n, true
+		err = checkRange(0, 6)
+	case 'u':
+		r.weekday, r.hasWeekday = n%7, true
+		err = checkRange(1, 7)
+	case 'G':
+		r.isoYear = n
+	case 'V':
+		r.isoWeek = n
+		err = checkRange(1, 53)
+	}
+	return v, err
+}
+
+func (r *strptimeResult) consumeOffset(v string, colon bool) (string, error) {
+	if len(v) > 0 && (v[0] == 'Z' || v[0] == 'z') {
+		r.loc = time.UTC
+		return v[1:], nil
+	}
+	if len(v) < 5 || (v[0] != '+' && v[0] != '-') {
+		return v, fmt.Errorf("expected UTC offset")
+	}
+	hh, mm, rest := v[1:3], "", ""
+	switch {
+	case v[3] == ':' && len(v) >= 6:
+		mm, rest = v[4:6], v[6:]
+	case v[3] != ':' && !colon:
+		mm, rest = v[3:5], v[5:]
+	default:
+		return v, fmt.Errorf("expected UTC offset")
+	}
+	h, err1 := strconv.Atoi(hh)
+	m, err2 := strconv.Atoi(mm)
+	if err1 != nil || err2 != nil || h > 23 || m > 59 {
+		return v, fmt.Errorf("invalid UTC offset:\"%s\"", v[:len(v)-len(rest)])
+	}
+	offset := h*3600 + m*60
+	if v[0] == '-' {
+		offset = -offset
+	}
+	r.loc = time.FixedZone("", offset)
+	return rest, nil
+}
+
+// consumeName consumes (case-insensitively) one of n names. If abbr is true, only the
+// first three letters of the name are matched.
+func consumeName(v string, n int, name func(int) string, abbr bool) (int, string, error) {
+	for i := 0; i < n; i++ {
+		s := name(i)
+		if abbr {
+			s = s[:3]
+		}
+		if len(v) >= len(s) && strings.EqualFold(v[:len(s)], s) {
+			return i, v[len(s):], nil
+		}
+	}
+	return 0, v, fmt.Errorf("unknown name")
+}
+
+// time builds the time.Time from the parsed components, following Python's _strptime.
+func (r *strptimeResult) time(value string) (time.Time, error) {
+	if r.unix != nil {
+		return time.Unix(*r.unix, int64(r.nanos)).In(r.loc), nil
+	}
+	hour := r.hour
+	if r.hasAMPM {
+		switch {
+		case r.pm && hour < 12:
+			hour += 12
+		case !r.pm && hour == 12:
+			hour = 0
+		}
+	}
+	year, month, day := r.year, r.month, r.day
+	switch {
+	case r.isoYear != 0 && r.isoWeek != 0 && r.hasWeekday:
+		// ISO 8601 week date. Week 1 is the week with the year's first Thursday.
+		jan4 := time.Date(r.isoYear, time.January, 4, 0, 0, 0, 0, time.UTC)
+		isoWeekday := (int(jan4.Weekday()) + 6) % 7
+		isoDay := (r.weekday + 6) % 7
+		d := jan4.AddDate(0, 0, -isoWeekday+(r.isoWeek-1)*7+isoDay)
+		year, month, day = d.Year(), int(d.Month()), d.Day()
+	case r.weekNumberVerb != 0 && r.hasWeekday && !r.hasMonthOrDay && r.yday == 0:
+		jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		var firstWeekday, weekday int // Relative to the first day of the week.
+		if r.weekNumberVerb == 'U' {
+			firstWeekday, weekday = int(jan1.Weekday()), r.weekday
+		} else {
+			firstWeekday, weekday = (int(jan1.Weekday())+6)%7, (r.weekday+6)%7
+		}
+		// Days before the first week start belong to week 0.
+		firstWeekStart := (7 - firstWeekday) % 7
+		var offset int
+		if r.weekNumber == 0 {
+			offset = weekday - firstWeekday
+		} else {
+			offset = firstWeekStart + (r.weekNumber-1)*7 + weekday
+		}
+		d := jan1.AddDate(0, 0, offset)
+		if d.Year() != year {
+			return time.Time{}, fmt.Errorf("invalid week date:\"%s\"", value)
+		}
+		year, month, day = d.Year(), int(d.Month()), d.Day()
+	case r.yday != 0 && !r.hasMonthOrDay:
+		d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, r.yday-1)
+		if d.Year() != year {
+			return time.Time{}, fmt.Errorf("day of year out of range:\"%s\"", value)
+		}
+		year, month, day = d.Year(), int(d.Month()), d.Day()
+	}
+	t := time.Date(year, time.Month(month), day, hour, r.minute, r.second, r.nanos, r.loc)
+	if t.Day() != day || int(t.Month()) != month {
+		return time.Time{}, fmt.Errorf("day out of range for month:\"%s\"", value)
+	}
+	if r.zone != "" {
+		return r.inZone(t, value)
+	}
+	return t, nil
+}
+
+// inZone returns the time whose wall clock is the one of t and whose zone name in
+// the location is the parsed one. Both times of the repeated hour when daylight saving
+// time ends (e.g. "01:30 EDT" and "01:30 EST") are tried.
+func (r *strptimeResult) inZone(t time.Time, value string) (time.Time, error) {
+	for _, d := range []time.Duration{0, -time.Hour, time.Hour} {
+		u := t.Add(d)
+		if name, _ := u.Zone(); strings.EqualFold(name, r.zone) && u.Hour() == t.Hour() && u.Minute() == t.Minute() {
+			return u, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time zone name:\"%s\" in \"%s\", the location %s does not use it", r.zone, value, r.loc)
+}
+
+// strftime formats t according to the passed-in strftime format.
+func strftime(format string, t time.Time) (string, error) {
+	tokens, err := tokenizeStrftime(format)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	for _, tok := range tokens {
+		if tok.verb == 0 {
+			b.WriteString(tok.literal)
+			continue
+		}
+		b.WriteString(formatStrftimeDirective(tok, t))
+	}
+	return b.String(), nil
+}
+
+func formatStrftimeDirective(tok strftimeToken, t time.Time) string {
+	num := func(n, width int) string {
+		s := strconv.Itoa(n)
+		pad := byte('0')
+		if strftimeSpacePadded[tok.verb] {
+			pad = ' '
+		}
+		switch tok.flag {
+		case '-':
+			return s
+		case '_':
+			pad = ' '
+		case '0':
+			pad = '0'
+		}
+		if len(s) < width {
+			s = strings.Repeat(string(pad), width-len(s)) + s
+		}
+		return s
+	}
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+	yday0 := t.YearDay() - 1
+	switch tok.verb {
+	case 'a':
+		return t.Weekday().String()[:3]
+	case 'A':
+		return t.Weekday().String()
+	case 'b', 'h':
+		return t.Month().String()[:3]
+	case 'B':
+		return t.Month().String()
+	case 'd', 'e':
+		return num(t.Day(), 2)
+	case 'm':
+		return num(int(t.Month()), 2)
+	case 'y':
+		return num(t.Year()%100, 2)
+	case 'Y':
+		return num(t.Year(), 4)
+	case 'H', 'k':
+		return num(t.Hour(), 2)
+	case 'I', 'l':
+		return num(hour12, 2)
+	case 'M':
+		return num(t.Minute(), 2)
+	case 'S':
+		return num(t.Second(), 2)
+	case 'f':
+		return fmt.Sprintf("%06d", t.Nanosecond()/1000)
+	case 'p':
+		if t.Hour() < 12 {
+			return "AM"
+		}
+		return "PM"
+	case 'z', 'Z':
+		name, offset := t.Zone()
+		// Names which look like offsets, e.g. "-03", are formatted as offsets.
+		if tok.verb == 'Z' && name != "" && name[0] != '+' && name[0] != '-' {
+			return name
+		}
+		sign := '+'
+		if offset < 0 {
+			sign, offset = '-', -offset
+		}
+		if tok.flag == ':' {
+			return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
+		}
+		return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+	case 'j':
+		return num(t.YearDay(), 3)
+	case 'U':
+		return num((yday0+7-int(t.Weekday()))/7, 2)
+	case 'W':
+		return num((yday0+7-(int(t.Weekday())+6)%7)/7, 2)
+	case 'w':
+		return strconv.Itoa(int(t.Weekday()))
+	case 'u':
+		return strconv.Itoa((int(t.Weekday())+6)%7 + 1)
+	case 'G':
+		y, _ := t.ISOWeek()
+		return num(y, 4)
+	case 'V':
+		_, w := t.ISOWeek()
+		return num(w, 2)
+	case 's':
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestStrptime(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  string
+			want   time.Time
+		}{
+			{"ISODate", "%Y-%m-%d", "2017-12-31", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"NoPadding", "%-d/%-m/%Y", "1/2/2017", time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{"NoPaddingBeforeTwoDigits", "%-m%d", "1231", time.Date(0, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"SpacePaddedDay", "%e %b %Y", " 3 Jan 2017", time.Date(2017, 1, 3, 0, 0, 0, 0, time.UTC)},
+			{"MonthNames", "%A, %B %d, %Y", "sunday, december 31, 2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"AbbreviatedNames", "%a %h %d %y", "Sun Dec 31 17", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"TwoDigitYearLastCentury", "%y", "69", time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{"DayOfYear", "%Y %j", "2016 366", time.Date(2016, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"WeekNumberSunday", "%Y %U %w", "2017 53 0", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"WeekNumberMonday", "%Y %W %a", "2017 01 Mon", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{"WeekZero", "%Y %W %a", "2017 00 Sun", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{"ISOWeek", "%G-W%V-%u", "2020-W53-5", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{"Percent", "%d%%%m", "31%12", time.Date(0, 12, 31, 0, 0, 0, 0, time.UTC)},
+			{"Composites", "%D %T", "12/31/17 13:45:10", time.Date(2017, 12, 31, 13, 45, 10, 0, time.UTC)},
+			{"LocaleComposites", "%c", "Sun Dec 31 13:45:10 2017", time.Date(2017, 12, 31, 13, 45, 10, 0, time.UTC)},
+			{"HourPM", "%I:%M %p", "01:45 pm", time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)},
+			{"HourMidnight", "%I:%M %p", "12:00 AM", time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{"Microseconds", "%H:%M:%S.%f", "13:45:10.5", time.Date(0, 1, 1, 13, 45, 10, 500000000, time.UTC)},
+			{"Offset", "%Y-%m-%dT%H:%M%z", "2017-12-31T13:45-0300", time.Date(2017, 12, 31, 16, 45, 0, 0, time.UTC)},
+			{"OffsetWithColon", "%H:%M%:z", "13:45+01:00", time.Date(0, 1, 1, 12, 45, 0, 0, time.UTC)},
+			{"OffsetZulu", "%H:%M%z", "13:45Z", time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)},
+			{"ZoneName", "%H:%M %Z", "13:45 GMT", time.Date(0, 1, 1, 13, 45, 0, 0, time.UTC)},
+			{"ZoneOffset", "%H:%M %Z", "13:45 -0300", time.Date(0, 1, 1, 16, 45, 0, 0, time.UTC)},
+			{"EpochSeconds", "%s", "1514728800", time.Date(2017, 12, 31, 14, 0, 0, 0, time.UTC)},
+			{"Whitespace", "%d %m %Y", "31   12\t2017", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC)},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := strptime(d.format, d.value, time.UTC)
+				is.NoErr(err)
+				is.True(got.Equal(d.want)) // times must be equal
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  string
+		}{
+			{"UnsupportedDirective", "%Q", "1"},
+			{"UnsupportedColonDirective", "%:d", "1"},
+			{"IncompleteDirective", "%Y-%", "2017-"},
+			{"LiteralMismatch", "%Y-%m", "2017/12"},
+			{"UnconvertedData", "%Y", "2017-12"},
+			{"ShortYear", "%Y", "17"},
+			{"MonthOutOfRange", "%m", "13"},
+			{"DayOutOfRange", "%Y-%m-%d", "2017-02-30"},
+			{"DayOfYearOutOfRange", "%Y %j", "2017 366"},
+			{"InvalidName", "%b", "Foo"},
+			{"InvalidAMPM", "%I %p", "10 XM"},
+			{"InvalidOffset", "%z", "+2500"},
+			{"UnknownZoneName", "%Z", "XYZ"},
+			{"NanosecondFraction", "%S.%f", "10.123456789"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := strptime(d.format, d.value, time.UTC)
+				is.True(err != nil)
+			})
+		}
+	})
+	t.Run("LocationZoneName", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip(err)
+		}
+		data := []struct {
+			desc  string
+			value string
+			want  time.Time
+		}{
+			{"Standard", "2017-12-31 13:45 EST", time.Date(2017, 12, 31, 18, 45, 0, 0, time.UTC)},
+			{"DaylightSaving", "2017-07-01 13:45 EDT", time.Date(2017, 7, 1, 17, 45, 0, 0, time.UTC)},
+			{"RepeatedHourDaylightSaving", "2017-11-05 01:30 EDT", time.Date(2017, 11, 5, 5, 30, 0, 0, time.UTC)},
+			{"RepeatedHourStandard", "2017-11-05 01:30 EST", time.Date(2017, 11, 5, 6, 30, 0, 0, time.UTC)},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := strptime("%Y-%m-%d %H:%M %Z", d.value, ny)
+				is.NoErr(err)
+				is.True(got.Equal(d.want)) // times must be equal
+			})
+		}
+		t.Run("Error", func(t *testing.T) {
+			is := is.New(t)
+			for _, v := range []string{"2017-07-01 13:45 EST", "2017-12-31 13:45 PST"} {
+				_, err := strptime("%Y-%m-%d %H:%M %Z", v, ny)
+				is.True(err != nil) // the location does not use the zone name
+			}
+		})
+	})
+}
+
+func TestStrftime(t *testing.T) {
+	sp := time.FixedZone("", -3*3600)
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  time.Time
+			want   string
+		}{
+			{"ISODate", "%Y-%m-%d", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), "2017-01-02"},
+			{"Padding", "%-d/%-m|%e|%_m|%0e", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), "2/1| 2| 1|02"},
+			{"Names", "%a %A %b %h %B", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), "Mon Monday Jan Jan January"},
+			{"TwelveHours", "%I:%M:%S %p|%l", time.Date(2017, 1, 2, 0, 5, 6, 0, time.UTC), "12:05:06 AM|12"},
+			{"Composites", "%D %T %F %R", time.Date(2017, 1, 2, 13, 5, 6, 0, time.UTC), "01/02/17 13:05:06 2017-01-02 13:05"},
+			{"Microseconds", "%S.%f", time.Date(2017, 1, 2, 13, 5, 6, 1500, time.UTC), "06.000001"},
+			{"Offsets", "%z %:z %Z", time.Date(2017, 1, 2, 0, 0, 0, 0, sp), "-0300 -03:00 -0300"},
+			{"UTC", "%z %Z", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), "+0000 UTC"},
+			{"Weeks", "%j %U %W %w %u", time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC), "365 53 52 0 7"},
+			{"ISOWeek", "%G-W%V-%u", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "2020-W53-5"},
+			{"Escapes", "%%%n%t", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC), "%\n\t"},
+			{"EpochSeconds", "%s", time.Date(2017, 12, 31, 14, 0, 0, 0, time.UTC), "1514728800"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := strftime(d.format, d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("UnsupportedDirective", func(t *testing.T) {
+		is := is.New(t)
+		_, err := strftime("%Y-%Q", time.Now())
+		is.True(err != nil)
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		is := is.New(t)
+		for _, want := range []time.Time{
+			time.Date(2017, 12, 31, 13, 45, 10, 123456000, time.UTC),
+			time.Date(2017, 12, 31, 13, 45, 10, 123456000, time.FixedZone("", -3*3600)),
+		} {
+			for _, f := range []string{"%Y-%m-%dT%H:%M:%S.%f%z", "%A %d %B %Y %I:%M:%S.%f %p %Z", "%G %V %u %T.%f%z"} {
+				s, err := strftime(f, want)
+				is.NoErr(err)
+				got, err := strptime(f, s, time.UTC)
+				is.NoErr(err)
+				is.True(got.Equal(want)) // round trip must not lose information
+			}
+		}
+	})
+}
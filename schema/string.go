@@ -1,19 +1,23 @@
 package schema
 
 import (
+	"encoding/base64"
 	"fmt"
-	"github.com/satori/go.uuid"
 	"net/mail"
 	"net/url"
+	"reflect"
+
+	"github.com/satori/go.uuid"
 )
 
 // Valid string formats and configuration.
 const (
-	stringURI         = "uri"
-	stringEmail       = "email"
-	stringUUID        = "uuid"
-	stringBinary      = "binary"
-	stringUUIDVersion = 4
+	stringURI    = "uri"
+	stringEmail  = "email"
+	stringUUID   = "uuid"
+	stringBinary = "binary"
+	// Highest UUID version defined by RFC 4122.
+	maxUUIDVersion = 5
 )
 
 func checkStringConstraints(v string, c Constraints) error {
@@ -34,7 +38,9 @@ func checkStringConstraints(v string, c Constraints) error {
 	return nil
 }
 
-func decodeString(format, value string, c Constraints) (string, error) {
+// decodeString decodes a string cell. If uuidVersion is not zero, UUIDs must be of
+// that version. Binary values must be decoded by decodeBinary.
+func decodeString(format string, uuidVersion int, value string, c Constraints) (string, error) {
 	err := checkStringConstraints(value, c)
 	if err != nil {
 		return value, err
@@ -48,12 +54,72 @@ func decodeString(format, value string, c Constraints) (string, error) {
 		_, err := mail.ParseAddress(value)
 		return value, err
 	case stringUUID:
-		v, err := uuid.FromString(value)
-		if v.Version() != stringUUIDVersion {
-			return value, fmt.Errorf("invalid UUID version - got:%d want:%d", v.Version(), stringUUIDVersion)
-		}
+		return value, checkUUID(value, uuidVersion)
+	case stringBinary:
+		_, err := decodeBase64(value)
 		return value, err
 	}
 	// NOTE: Returning the value for unknown format is in par with the python library.
 	return value, nil
 }
+
+func checkUUID(value string, version int) error {
+	v, err := uuid.FromString(value)
+	if err != nil {
+		return err
+	}
+	if v.Variant() != uuid.VariantRFC4122 || v.Version() < 1 || v.Version() > maxUUIDVersion {
+		return fmt.Errorf("invalid UUID:%s is not a RFC 4122 UUID", value)
+	}
+	if version != 0 && int(v.Version()) != version {
+		return fmt.Errorf("invalid UUID version - got:%d want:%d", v.Version(), version)
+	}
+	return nil
+}
+
+// decodeBinary decodes a base64 string cell. Length constraints are checked against
+// the decoded bytes while the pattern is checked against the encoded value.
+func decodeBinary(value string, c Constraints) ([]byte, error) {
+	b, err := decodeBase64(value)
+	if err != nil {
+		return nil, err
+	}
+	if c.MinLength != 0 && len(b) < c.MinLength {
+		return nil, fmt.Errorf("constraint check error: binary length %v < minimum:%v", len(b), c.MinLength)
+	}
+	if c.MaxLength != 0 && len(b) > c.MaxLength {
+		return nil, fmt.Errorf("constraint check error: binary length %v > maximum:%v", len(b), c.MaxLength)
+	}
+	if c.compiledPattern != nil && !c.compiledPattern.MatchString(value) {
+		return nil, fmt.Errorf("constraint check error: %v don't fit pattern : %v ", value, c.Pattern)
+	}
+	return b, nil
+}
+
+// decodeBase64 decodes standard base64, with or without padding.
+func decodeBase64(value string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		if raw, rawErr := base64.RawStdEncoding.DecodeString(value); rawErr == nil {
+			return raw, nil
+		}
+		return nil, fmt.Errorf("invalid binary:\"%s\" is not base64 encoded: %v", value, err)
+	}
+	return b, nil
+}
+
+func encodeString(format string, in interface{}) (string, error) {
+	switch v := in.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		if format == stringBinary {
+			return base64.StdEncoding.EncodeToString(v), nil
+		}
+	case uuid.UUID:
+		if format == stringUUID {
+			return v.String(), nil
+		}
+	}
+	return "", fmt.Errorf("can not convert \"%v\" which type is %s to type %s", in, reflect.TypeOf(in), StringType)
+}
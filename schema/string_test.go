@@ -2,23 +2,156 @@ package schema
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/matryer/is"
+	"github.com/satori/go.uuid"
 )
 
 // To be in par with the python library.
 func TestDecodeString_URIMustRequireScheme(t *testing.T) {
 	is := is.New(t)
-	_, err := decodeString(stringURI, "google.com", Constraints{})
+	_, err := decodeString(stringURI, 0, "google.com", Constraints{})
 	is.True(err != nil)
 }
 
-func TestDecodeString_InvalidUUIDVersion(t *testing.T) {
-	is := is.New(t)
-	// This is a uuid3: namespace DNS and python.org.
-	_, err := decodeString(stringUUID, "6fa459ea-ee8a-3ca4-894e-db77e160355e", Constraints{})
-	is.True(err != nil)
+func TestDecodeString_UUID(t *testing.T) {
+	t.Run("AllVersions", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+		}{
+			{"V1", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+			{"V2", "000003e8-dad1-21e8-a100-325096b39f47"},
+			// namespace DNS and python.org.
+			{"V3", "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+			{"V4", "c56a4180-65aa-42ec-a945-5fd21dec0538"},
+			{"V5", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeString(stringUUID, 0, d.value, Constraints{})
+				is.NoErr(err)
+			})
+		}
+	})
+	t.Run("Version", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeString(stringUUID, 3, "6fa459ea-ee8a-3ca4-894e-db77e160355e", Constraints{})
+		is.NoErr(err)
+		_, err = decodeString(stringUUID, 4, "6fa459ea-ee8a-3ca4-894e-db77e160355e", Constraints{})
+		is.True(err != nil)
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+		}{
+			{"Malformed", "6fa459ea-ee8a-3ca4-894e"},
+			{"Nil", "00000000-0000-0000-0000-000000000000"},
+			{"UnknownVersion", "6fa459ea-ee8a-7ca4-894e-db77e160355e"},
+			{"NonRFC4122Variant", "6fa459ea-ee8a-3ca4-c94e-db77e160355e"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeString(stringUUID, 0, d.value, Constraints{})
+				is.True(err != nil)
+			})
+		}
+	})
+	t.Run("MalformedReportsParseError", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeString(stringUUID, 4, "foo", Constraints{})
+		is.True(err != nil)
+		is.True(!strings.Contains(err.Error(), "version"))
+	})
+}
+
+func TestDecodeBinary(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+			want  []byte
+		}{
+			{"Padded", "Zm9vYg==", Constraints{}, []byte("foob")},
+			{"Unpadded", "Zm9vYg", Constraints{}, []byte("foob")},
+			{"Empty", "", Constraints{}, []byte{}},
+			{"Length", "Zm9vYg==", Constraints{MinLength: 4, MaxLength: 4}, []byte("foob")},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := decodeBinary(d.value, d.c)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+			c     Constraints
+		}{
+			{"NotBase64", "foo*", Constraints{}},
+			{"MinLength", "Zm9vYg==", Constraints{MinLength: 5}},
+			{"MaxLength", "Zm9vYg==", Constraints{MaxLength: 3}},
+			{"Pattern", "Zm9vYg==", Constraints{compiledPattern: regexp.MustCompile("^a"), Pattern: "^a"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeBinary(d.value, d.c)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestEncodeString(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  interface{}
+			want   string
+		}{
+			{"String", defaultFieldFormat, "foo", "foo"},
+			{"Binary", stringBinary, []byte("foob"), "Zm9vYg=="},
+			{"UUID", stringUUID, uuid.FromStringOrNil("6fa459ea-ee8a-3ca4-894e-db77e160355e"), "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := encodeString(d.format, d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  interface{}
+		}{
+			{"Int", defaultFieldFormat, 10},
+			{"BytesNotBinary", defaultFieldFormat, []byte("foo")},
+			{"UUIDNotUUIDFormat", defaultFieldFormat, uuid.UUID{}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := encodeString(d.format, d.value)
+				is.True(err != nil)
+			})
+		}
+	})
 }
 
 func TestDecodeString_ErrorCheckingConstraints(t *testing.T) {
@@ -41,7 +174,7 @@ func TestDecodeString_ErrorCheckingConstraints(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			_, err := decodeString(d.format, d.value, d.constraints)
+			_, err := decodeString(d.format, 0, d.value, d.constraints)
 			is.True(err != nil)
 		})
 	}
@@ -61,7 +194,7 @@ func TestDecodeString_Success(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.desc, func(t *testing.T) {
 			is := is.New(t)
-			v, err := decodeString(d.format, d.value, d.constraints)
+			v, err := decodeString(d.format, 0, d.value, d.constraints)
 			is.NoErr(err)
 			is.Equal(v, d.value)
 		})
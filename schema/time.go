@@ -3,39 +3,148 @@ package schema
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 )
 
-func decodeTime(format, value string, c Constraints) (time.Time, error) {
-	y, err := decodeTimeWithoutCheckConstraints(format, value)
-	if err != nil {
-		return y, err
+// TimeOfDay represents a "time" cell: a time of the day, without date.
+// More at: https://specs.frictionlessdata.io/table-schema/#time
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+	// Offset is the UTC offset of the time of day in seconds east of UTC. It is zero
+	// unless the field keeps the source offset (see Field.KeepOffset).
+	Offset int
+}
+
+// ParseTimeOfDay parses a time of day using the default time format, that is
+// ISO 8601 HH:MM:SS with optional fractional seconds and UTC offset. The offset
+// (if any) is kept.
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	return decodeTimeWithoutCheckConstraints(defaultFieldFormat, temporalOptions{keepOffset: true}, value)
+}
+
+func timeOfDayOf(t time.Time) TimeOfDay {
+	_, offset := t.Zone()
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond(), Offset: offset}
+}
+
+// String returns the ISO 8601 representation of the time of day. Fractional seconds
+// and offset are only present if they are not zero.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
 	}
-	var max, min time.Time
-	if c.Maximum != "" {
-		max, err = decodeTimeWithoutCheckConstraints(format, c.Maximum)
-		if err != nil {
-			return y, err
+	if t.Offset != 0 {
+		offset, sign := t.Offset, '+'
+		if offset < 0 {
+			offset, sign = -offset, '-'
 		}
+		s += fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
 	}
-	if c.Minimum != "" {
-		min, err = decodeTimeWithoutCheckConstraints(format, c.Minimum)
-		if err != nil {
-			return y, err
-		}
+	return s
+}
+
+// On returns the time.Time corresponding to the time of day on the date of d.
+func (t TimeOfDay) On(d time.Time) time.Time {
+	loc := time.UTC
+	if t.Offset != 0 {
+		loc = time.FixedZone("", t.Offset)
 	}
-	return checkConstraints(y, max, min, TimeType)
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
 }
 
-func decodeTimeWithoutCheckConstraints(format, value string) (time.Time, error) {
-	return decodeDefaultOrCustomTime("03:04:05", format, value)
+// Compare returns -1, 0 or 1 if t is respectively before, equal or after o, once
+// both are converted to UTC.
+func (t TimeOfDay) Compare(o TimeOfDay) int {
+	a, b := t.utcNanos(), o.utcNanos()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
 }
 
-func encodeTime(v interface{}) (string, error) {
+func (t TimeOfDay) utcNanos() int64 {
+	secs := int64(t.Hour*3600+t.Minute*60+t.Second) - int64(t.Offset)
+	return secs*int64(time.Second) + int64(t.Nanosecond)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+func decodeTime(format string, opts temporalOptions, value string, c Constraints) (TimeOfDay, error) {
+	t, err := decodeTimeWithoutCheckConstraints(format, opts, value)
+	if err != nil {
+		return t, err
+	}
+	return t, checkBounds(t, c, timeOrdering(format, opts))
+}
+
+// Layouts accepted by the time default format (ISO 8601 HH:MM:SS). Fractional seconds
+// are always accepted by the Go parser. Values without UTC offset are interpreted in
+// the field location.
+var defaultTimeLayouts = []string{"15:04:05", "15:04:05Z07:00"}
+
+// Date used to find out the offset of locations when decoding times of day, which
+// do not have dates and consequently can not tell whether daylight saving time applies.
+var timeOfDayReferenceDate = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func decodeTimeWithoutCheckConstraints(format string, opts temporalOptions, value string) (TimeOfDay, error) {
+	if opts.loc != nil {
+		name, offset := timeOfDayReferenceDate.In(opts.loc).Zone()
+		opts.loc = time.FixedZone(name, offset)
+	}
+	t, err := decodeDefaultOrCustomTime(TimeType, defaultTimeLayouts, format, opts, value)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return timeOfDayOf(t), nil
+}
+
+func encodeTime(format string, v interface{}) (string, error) {
 	value, ok := v.(time.Time)
 	if !ok {
 		return "", fmt.Errorf("invalid date - value:%v type:%v", v, reflect.ValueOf(v).Type())
 	}
-	utc := value.In(time.UTC)
-	return utc.Format(time.RFC3339), nil
+	switch format {
+	case "", defaultFieldFormat, AnyDateFormat:
+		utc := value.In(time.UTC)
+		return utc.Format(time.RFC3339), nil
+	}
+	return strftime(format, value)
+}
+
+func encodeTimeOfDay(format string, v interface{}) (string, error) {
+	var t TimeOfDay
+	switch value := v.(type) {
+	case TimeOfDay:
+		t = value
+	case time.Time:
+		t = timeOfDayOf(value)
+	default:
+		return "", fmt.Errorf("invalid time - value:%v type:%v", v, reflect.ValueOf(v).Type())
+	}
+	switch format {
+	case "", defaultFieldFormat, AnyDateFormat:
+		return t.String(), nil
+	}
+	return strftime(format, t.On(time.Time{}))
 }
@@ -8,14 +8,38 @@ import (
 )
 
 func TestDecodeTime(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  string
+			want   TimeOfDay
+		}{
+			{"Morning", defaultFieldFormat, "09:05:00", TimeOfDay{Hour: 9, Minute: 5}},
+			{"Afternoon", defaultFieldFormat, "13:45:10", TimeOfDay{Hour: 13, Minute: 45, Second: 10}},
+			{"Midnight", defaultFieldFormat, "00:00:00", TimeOfDay{}},
+			{"FractionalSeconds", defaultFieldFormat, "23:59:59.999", TimeOfDay{Hour: 23, Minute: 59, Second: 59, Nanosecond: 999000000}},
+			{"Zulu", defaultFieldFormat, "13:45:00Z", TimeOfDay{Hour: 13, Minute: 45}},
+			{"CustomFormat", "%I:%M %p", "01:45 PM", TimeOfDay{Hour: 13, Minute: 45}},
+			{"AnyFormat", AnyDateFormat, "1:45PM", TimeOfDay{Hour: 13, Minute: 45}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := decodeTime(d.format, noTemporalOptions, d.value, Constraints{})
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
 	t.Run("ValidMaximum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeTime(defaultFieldFormat, "11:45:00", Constraints{Maximum: "11:45:01"})
+		_, err := decodeTime(defaultFieldFormat, noTemporalOptions, "11:45:00", Constraints{Maximum: "11:45:01"})
 		is.NoErr(err)
 	})
 	t.Run("ValidMinimum", func(t *testing.T) {
 		is := is.New(t)
-		_, err := decodeTime(defaultFieldFormat, "11:45:00", Constraints{Minimum: "11:44:59"})
+		_, err := decodeTime(defaultFieldFormat, noTemporalOptions, "11:45:00", Constraints{Minimum: "11:44:59"})
 		is.NoErr(err)
 	})
 	t.Run("Error", func(t *testing.T) {
@@ -25,6 +49,9 @@ func TestDecodeTime(t *testing.T) {
 			constraints Constraints
 		}{
 			{"InvalidYear", "foo", Constraints{}},
+			{"InvalidHour", "24:00:00", Constraints{}},
+			{"MissingSeconds", "13:45", Constraints{}},
+			{"OffsetBiggerThanMaximum", "11:45:00-01:00", Constraints{Maximum: "12:00:00"}},
 			{"BiggerThanMaximum", "11:45:00", Constraints{Maximum: "11:44:59"}},
 			{"InvalidMaximum", "11:45:00", Constraints{Maximum: "boo"}},
 			{"SmallerThanMinimum", "11:45:00", Constraints{Minimum: "11:45:01"}},
@@ -33,13 +60,108 @@ func TestDecodeTime(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := decodeTime(defaultFieldFormat, d.time, d.constraints)
+				_, err := decodeTime(defaultFieldFormat, noTemporalOptions, d.time, d.constraints)
 				is.True(err != nil)
 			})
 		}
 	})
 }
 
+func TestDecodeTime_TimeZones(t *testing.T) {
+	t.Run("OffsetToUTC", func(t *testing.T) {
+		is := is.New(t)
+		got, err := decodeTime(defaultFieldFormat, noTemporalOptions, "10:45:00+02:00", Constraints{})
+		is.NoErr(err)
+		is.Equal(got, TimeOfDay{Hour: 8, Minute: 45})
+	})
+	t.Run("KeepOffset", func(t *testing.T) {
+		is := is.New(t)
+		got, err := decodeTime(defaultFieldFormat, temporalOptions{keepOffset: true}, "10:45:00.5-03:00", Constraints{})
+		is.NoErr(err)
+		is.Equal(got, TimeOfDay{Hour: 10, Minute: 45, Nanosecond: 500000000, Offset: -3 * 3600})
+	})
+	t.Run("NaiveLocation", func(t *testing.T) {
+		is := is.New(t)
+		got, err := decodeTime(defaultFieldFormat, temporalOptions{loc: time.FixedZone("", 3600)}, "10:45:00", Constraints{})
+		is.NoErr(err)
+		is.Equal(got, TimeOfDay{Hour: 9, Minute: 45})
+	})
+	t.Run("NaiveLocationWithDST", func(t *testing.T) {
+		is := is.New(t)
+		loc, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip("time zone database not available")
+		}
+		got, err := decodeTime(defaultFieldFormat, temporalOptions{loc: loc, keepOffset: true}, "10:45:00", Constraints{})
+		is.NoErr(err)
+		is.Equal(got, TimeOfDay{Hour: 10, Minute: 45, Offset: -5 * 3600})
+	})
+}
+
+func TestTimeOfDay(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(TimeOfDay{Hour: 13, Minute: 5, Second: 9}.String(), "13:05:09")
+		is.Equal(TimeOfDay{Hour: 13, Nanosecond: 500000000}.String(), "13:00:00.5")
+		is.Equal(TimeOfDay{Hour: 13, Offset: -(3*3600 + 30*60)}.String(), "13:00:00-03:30")
+	})
+	t.Run("ParseKeepsOffset", func(t *testing.T) {
+		is := is.New(t)
+		got, err := ParseTimeOfDay("13:00:00+01:00")
+		is.NoErr(err)
+		is.Equal(got, TimeOfDay{Hour: 13, Offset: 3600})
+		_, err = ParseTimeOfDay("1:00 PM")
+		is.True(err != nil)
+	})
+	t.Run("Compare", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(TimeOfDay{Hour: 13}.Compare(TimeOfDay{Hour: 14, Offset: 3600}), 0)
+		is.Equal(TimeOfDay{Hour: 13}.Compare(TimeOfDay{Hour: 13, Nanosecond: 1}), -1)
+		is.Equal(TimeOfDay{Hour: 13, Offset: -3600}.Compare(TimeOfDay{Hour: 13}), 1)
+	})
+	t.Run("On", func(t *testing.T) {
+		is := is.New(t)
+		got := TimeOfDay{Hour: 13, Offset: 3600}.On(time.Date(2017, 12, 31, 0, 0, 0, 0, time.UTC))
+		is.True(got.Equal(time.Date(2017, 12, 31, 12, 0, 0, 0, time.UTC)))
+	})
+	t.Run("TextMarshaling", func(t *testing.T) {
+		is := is.New(t)
+		var got TimeOfDay
+		is.NoErr(got.UnmarshalText([]byte("08:30:00.25")))
+		b, err := got.MarshalText()
+		is.NoErr(err)
+		is.Equal(string(b), "08:30:00.25")
+	})
+}
+
+func TestEncodeTimeOfDay(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		data := []struct {
+			desc   string
+			format string
+			value  interface{}
+			want   string
+		}{
+			{"TimeOfDay", defaultFieldFormat, TimeOfDay{Hour: 13, Minute: 45}, "13:45:00"},
+			{"Time", defaultFieldFormat, time.Date(2017, 12, 31, 13, 45, 0, 0, time.UTC), "13:45:00"},
+			{"CustomFormat", "%-I:%M %p", TimeOfDay{Hour: 13, Minute: 45}, "1:45 PM"},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				got, err := encodeTimeOfDay(d.format, d.value)
+				is.NoErr(err)
+				is.Equal(got, d.want)
+			})
+		}
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		is := is.New(t)
+		_, err := encodeTimeOfDay(defaultFieldFormat, "13:45:00")
+		is.True(err != nil)
+	})
+}
+
 func TestEncodeTime(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		data := []struct {
@@ -52,7 +174,7 @@ func TestEncodeTime(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				got, err := encodeTime(d.value)
+				got, err := encodeTime(defaultFieldFormat, d.value)
 				is.NoErr(err)
 				is.Equal(d.want, got)
 			})
@@ -68,7 +190,7 @@ func TestEncodeTime(t *testing.T) {
 		for _, d := range data {
 			t.Run(d.desc, func(t *testing.T) {
 				is := is.New(t)
-				_, err := encodeTime(d.value)
+				_, err := encodeTime(defaultFieldFormat, d.value)
 				is.True(err != nil)
 			})
 		}
@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TopoJSON object types, besides the GeoJSON geometry types.
+// More at: https://github.com/topojson/topojson-specification
+const (
+	TopoJSONTopology = "Topology"
+)
+
+// Topology represents a TopoJSON topology.
+// More at: https://github.com/topojson/topojson-specification#21-topology-objects
+type Topology struct {
+	Objects map[string]TopoGeometry
+	// Arcs are shared by the geometries, which refer to them by index. Positions
+	// are delta-encoded if the topology is quantized (i.e. Transform is not nil).
+	Arcs      [][]Position
+	Transform *TopoTransform
+	BBox      []float64
+}
+
+// TopoTransform is used to convert quantized positions to real coordinates.
+// More at: https://github.com/topojson/topojson-specification#212-transforms
+type TopoTransform struct {
+	Scale     [2]float64 `json:"scale"`
+	Translate [2]float64 `json:"translate"`
+}
+
+// TopoGeometry represents a TopoJSON geometry object. Points are defined by their
+// coordinates while the other geometries refer to the topology arcs. Negative arc
+// indexes (one's complement) mean the arc is reversed. An empty Type represents
+// the null geometry.
+// More at: https://github.com/topojson/topojson-specification#22-geometry-objects
+type TopoGeometry struct {
+	Type            string
+	ID              interface{}
+	Properties      map[string]interface{}
+	Point           Position
+	MultiPoint      []Position
+	LineString      []int
+	MultiLineString [][]int
+	Polygon         [][]int
+	MultiPolygon    [][][]int
+	Geometries      []TopoGeometry
+	BBox            []float64
+}
+
+// Validate checks whether t is a structurally valid TopoJSON topology.
+func (t Topology) Validate() error {
+	if t.Objects == nil {
+		return fmt.Errorf("invalid topojson: topology must have objects")
+	}
+	for _, arc := range t.Arcs {
+		if err := validateLineString(arc); err != nil {
+			return fmt.Errorf("invalid topojson: arc: %v", err)
+		}
+	}
+	for name, o := range t.Objects {
+		if err := o.validate(len(t.Arcs)); err != nil {
+			return fmt.Errorf("invalid topojson: object %s: %v", name, err)
+		}
+	}
+	return validateBBox(t.BBox)
+}
+
+func (g TopoGeometry) validate(numArcs int) error {
+	switch g.Type {
+	case "":
+	case GeoJSONPoint:
+		if err := validatePosition(g.Point); err != nil {
+			return err
+		}
+	case GeoJSONMultiPoint:
+		for _, p := range g.MultiPoint {
+			if err := validatePosition(p); err != nil {
+				return err
+			}
+		}
+	case GeoJSONLineString:
+		if err := validateArcIndexes(g.LineString, numArcs); err != nil {
+			return err
+		}
+	case GeoJSONMultiLineString, GeoJSONPolygon:
+		rings := g.MultiLineString
+		if g.Type == GeoJSONPolygon {
+			rings = g.Polygon
+		}
+		for _, r := range rings {
+			if err := validateArcIndexes(r, numArcs); err != nil {
+				return err
+			}
+		}
+	case GeoJSONMultiPolygon:
+		for _, p := range g.MultiPolygon {
+			for _, r := range p {
+				if err := validateArcIndexes(r, numArcs); err != nil {
+					return err
+				}
+			}
+		}
+	case GeoJSONGeometryCollection:
+		for _, c := range g.Geometries {
+			if err := c.validate(numArcs); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown geometry type:\"%s\"", g.Type)
+	}
+	return validateBBox(g.BBox)
+}
+
+func validateArcIndexes(indexes []int, numArcs int) error {
+	if len(indexes) == 0 {
+		return fmt.Errorf("geometry must refer to at least one arc")
+	}
+	for _, i := range indexes {
+		arc := i
+		if i < 0 {
+			arc = ^i
+		}
+		if arc >= numArcs {
+			return fmt.Errorf("arc index %d out of range, topology has %d arcs", i, numArcs)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Topology) MarshalJSON() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	arcs := t.Arcs
+	if arcs == nil {
+		arcs = [][]Position{}
+	}
+	return json.Marshal(struct {
+		Type      string                  `json:"type"`
+		Objects   map[string]TopoGeometry `json:"objects"`
+		Arcs      [][]Position            `json:"arcs"`
+		Transform *TopoTransform          `json:"transform,omitempty"`
+		BBox      []float64               `json:"bbox,omitempty"`
+	}{TopoJSONTopology, t.Objects, arcs, t.Transform, t.BBox})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Foreign members are ignored.
+func (t *Topology) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type      string                  `json:"type"`
+		Objects   map[string]TopoGeometry `json:"objects"`
+		Arcs      [][]Position            `json:"arcs"`
+		Transform *TopoTransform          `json:"transform"`
+		BBox      []float64               `json:"bbox"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("invalid topojson: %v", err)
+	}
+	if raw.Type != TopoJSONTopology {
+		return fmt.Errorf("invalid topojson: expected type %s, got:\"%s\"", TopoJSONTopology, raw.Type)
+	}
+	if raw.Arcs == nil {
+		return fmt.Errorf("invalid topojson: topology must have arcs")
+	}
+	topo := Topology{Objects: raw.Objects, Arcs: raw.Arcs, Transform: raw.Transform, BBox: raw.BBox}
+	if err := topo.Validate(); err != nil {
+		return err
+	}
+	*t = topo
+	return nil
+}
+
+func (g *TopoGeometry) members() (coordinates, arcs interface{}) {
+	switch g.Type {
+	case GeoJSONPoint:
+		return &g.Point, nil
+	case GeoJSONMultiPoint:
+		return &g.MultiPoint, nil
+	case GeoJSONLineString:
+		return nil, &g.LineString
+	case GeoJSONMultiLineString:
+		return nil, &g.MultiLineString
+	case GeoJSONPolygon:
+		return nil, &g.Polygon
+	case GeoJSONMultiPolygon:
+		return nil, &g.MultiPolygon
+	}
+	return nil, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (g TopoGeometry) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Type        interface{}            `json:"type"`
+		ID          interface{}            `json:"id,omitempty"`
+		Properties  map[string]interface{} `json:"properties,omitempty"`
+		Coordinates interface{}            `json:"coordinates,omitempty"`
+		Arcs        interface{}            `json:"arcs,omitempty"`
+		Geometries  interface{}            `json:"geometries,omitempty"`
+		BBox        []float64              `json:"bbox,omitempty"`
+	}{ID: g.ID, Properties: g.Properties, BBox: g.BBox}
+	if g.Type != "" {
+		out.Type = g.Type
+	}
+	out.Coordinates, out.Arcs = g.members()
+	if g.Type == GeoJSONGeometryCollection {
+		geometries := g.Geometries
+		if geometries == nil {
+			geometries = []TopoGeometry{}
+		}
+		out.Geometries = geometries
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Arc indexes are checked by Topology.
+func (g *TopoGeometry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type        *string                `json:"type"`
+		ID          interface{}            `json:"id"`
+		Properties  map[string]interface{} `json:"properties"`
+		Coordinates json.RawMessage        `json:"coordinates"`
+		Arcs        json.RawMessage        `json:"arcs"`
+		Geometries  []TopoGeometry         `json:"geometries"`
+		BBox        []float64              `json:"bbox"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	geo := TopoGeometry{ID: raw.ID, Properties: raw.Properties, BBox: raw.BBox}
+	if raw.Type != nil {
+		if *raw.Type == "" {
+			return fmt.Errorf("geometry type must not be empty")
+		}
+		geo.Type = *raw.Type
+	}
+	if geo.Type == GeoJSONGeometryCollection {
+		if raw.Geometries == nil {
+			return fmt.Errorf("geometry collection must have geometries")
+		}
+		geo.Geometries = raw.Geometries
+	}
+	coordinates, arcs := geo.members()
+	switch {
+	case coordinates != nil:
+		if raw.Coordinates == nil {
+			return fmt.Errorf("geometry %s must have coordinates", geo.Type)
+		}
+		if err := json.Unmarshal(raw.Coordinates, coordinates); err != nil {
+			return fmt.Errorf("%s coordinates: %v", geo.Type, err)
+		}
+	case arcs != nil:
+		if raw.Arcs == nil {
+			return fmt.Errorf("geometry %s must have arcs", geo.Type)
+		}
+		if err := json.Unmarshal(raw.Arcs, arcs); err != nil {
+			return fmt.Errorf("%s arcs: %v", geo.Type, err)
+		}
+	}
+	*g = geo
+	return nil
+}
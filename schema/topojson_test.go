@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeTopoJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		is := is.New(t)
+		got, err := decodeGeoJSON(GeoJSONTopoJSONFormat, `{
+			"type": "Topology",
+			"transform": {"scale": [0.5, 0.5], "translate": [100, 0]},
+			"objects": {
+				"example": {
+					"type": "GeometryCollection",
+					"geometries": [
+						{"type": "Point", "properties": {"prop0": "value0"}, "coordinates": [4000, 5000]},
+						{"type": "LineString", "arcs": [0]},
+						{"type": "Polygon", "id": "p", "arcs": [[-2]]},
+						{"type": null}
+					]
+				}
+			},
+			"arcs": [
+				[[4000, 0], [1999, 9999], [2000, -9999], [2000, 9999]],
+				[[0, 0], [0, 9999], [2000, 0], [0, -9999], [-2000, 0]]
+			]
+		}`)
+		is.NoErr(err)
+		want := Topology{
+			Transform: &TopoTransform{Scale: [2]float64{0.5, 0.5}, Translate: [2]float64{100, 0}},
+			Objects: map[string]TopoGeometry{
+				"example": {Type: GeoJSONGeometryCollection, Geometries: []TopoGeometry{
+					{Type: GeoJSONPoint, Properties: map[string]interface{}{"prop0": "value0"}, Point: Position{4000, 5000}},
+					{Type: GeoJSONLineString, LineString: []int{0}},
+					{Type: GeoJSONPolygon, ID: "p", Polygon: [][]int{{-2}}},
+					{},
+				}},
+			},
+			Arcs: [][]Position{
+				{{4000, 0}, {1999, 9999}, {2000, -9999}, {2000, 9999}},
+				{{0, 0}, {0, 9999}, {2000, 0}, {0, -9999}, {-2000, 0}},
+			},
+		}
+		is.Equal(got, want)
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc  string
+			value string
+		}{
+			{"NotJSON", `foo`},
+			{"GeoJSON", `{"type":"Point","coordinates":[1,1]}`},
+			{"MissingObjects", `{"type":"Topology","arcs":[]}`},
+			{"MissingArcs", `{"type":"Topology","objects":{}}`},
+			{"ShortArc", `{"type":"Topology","objects":{},"arcs":[[[0,0]]]}`},
+			{"ArcIndexOutOfRange", `{"type":"Topology","objects":{"a":{"type":"LineString","arcs":[1]}},"arcs":[[[0,0],[1,1]]]}`},
+			{"ReversedArcIndexOutOfRange", `{"type":"Topology","objects":{"a":{"type":"LineString","arcs":[-2]}},"arcs":[[[0,0],[1,1]]]}`},
+			{"EmptyArcs", `{"type":"Topology","objects":{"a":{"type":"Polygon","arcs":[[]]}},"arcs":[[[0,0],[1,1]]]}`},
+			{"MissingGeometryArcs", `{"type":"Topology","objects":{"a":{"type":"LineString"}},"arcs":[]}`},
+			{"MissingCoordinates", `{"type":"Topology","objects":{"a":{"type":"Point"}},"arcs":[]}`},
+			{"UnknownGeometry", `{"type":"Topology","objects":{"a":{"type":"Circle"}},"arcs":[]}`},
+			{"EmptyGeometryType", `{"type":"Topology","objects":{"a":{"type":""}},"arcs":[]}`},
+			{"InvalidBBox", `{"type":"Topology","objects":{},"arcs":[],"bbox":[1]}`},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeGeoJSON(GeoJSONTopoJSONFormat, d.value)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestEncodeTopoJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		is := is.New(t)
+		topo := Topology{
+			Objects: map[string]TopoGeometry{
+				"a": {Type: GeoJSONLineString, LineString: []int{0}},
+				"b": {},
+			},
+			Arcs: [][]Position{{{0, 0}, {1, 1}}},
+		}
+		got, err := encodeGeoJSON(GeoJSONTopoJSONFormat, topo)
+		is.NoErr(err)
+		is.Equal(got, `{"type":"Topology","objects":{"a":{"type":"LineString","arcs":[0]},"b":{"type":null}},"arcs":[[[0,0],[1,1]]]}`)
+	})
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := encodeGeoJSON(GeoJSONTopoJSONFormat, Topology{Objects: map[string]TopoGeometry{"a": {Type: GeoJSONLineString, LineString: []int{0}}}})
+		is.True(err != nil)
+	})
+}
@@ -0,0 +1,108 @@
+package schema
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Type is implemented by custom field types, which are made available to fields,
+// schemas and inference through RegisterType.
+type Type interface {
+	// Decode decodes the passed-in cell according to the field (e.g. its format).
+	Decode(f *Field, value string) (interface{}, error)
+	// Encode encodes the passed-in value, which has been dereferenced if it was a pointer,
+	// into a cell according to the field.
+	Encode(f *Field, v interface{}) (string, error)
+	// CheckConstraints checks whether a value returned by Decode satisfies the field
+	// constraints.
+	CheckConstraints(f *Field, v interface{}) error
+	// Infer reports whether the passed-in cell looks like a value of this type. It is
+	// used by Infer and InferImplicitCasting.
+	Infer(value string) bool
+}
+
+var (
+	builtinTypes = map[string]struct{}{
+		IntegerType: {}, StringType: {}, BooleanType: {}, NumberType: {}, DateType: {},
+		ObjectType: {}, ArrayType: {}, DateTimeType: {}, TimeType: {}, YearMonthType: {},
+		YearType: {}, DurationType: {}, GeoPointType: {}, GeoJSONType: {}, ListType: {},
+		AnyType: {},
+	}
+
+	typesMu sync.RWMutex
+	types   = make(map[string]Type)
+	// Names of the registered types, in registration order.
+	typeNames []string
+)
+
+// RegisterType makes a custom field type available under the passed-in name, which
+// can then be used as Field.Type. It returns an error if the name is empty, is a
+// built-in type or has already been registered.
+//
+// When inferring schemas, registered types are tried before the built-in ones and
+// in registration order. Values which are inferred as a registered type can only be
+// implicitly cast to string.
+func RegisterType(name string, t Type) error {
+	if name == "" || t == nil {
+		return fmt.Errorf("invalid type registration: name and type are mandatory")
+	}
+	if _, ok := builtinTypes[name]; ok {
+		return fmt.Errorf("invalid type registration: %s is a built-in type", name)
+	}
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	if _, ok := types[name]; ok {
+		return fmt.Errorf("invalid type registration: %s is already registered", name)
+	}
+	types[name] = t
+	typeNames = append(typeNames, name)
+	return nil
+}
+
+func lookupType(name string) (Type, bool) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	t, ok := types[name]
+	return t, ok
+}
+
+// isKnownType reports whether name is a built-in or registered type.
+func isKnownType(name string) bool {
+	if _, ok := builtinTypes[name]; ok {
+		return true
+	}
+	_, ok := lookupType(name)
+	return ok
+}
+
+// inferenceOrder returns the types tried by inference: registered types followed
+// by the built-in ones, from narrower to wider.
+func inferenceOrder() []string {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	if len(typeNames) == 0 {
+		return orderedTypes
+	}
+	order := make([]string, 0, len(typeNames)+len(orderedTypes))
+	order = append(order, typeNames...)
+	return append(order, orderedTypes...)
+}
+
+// implicitCastOrder returns the types a value inferred as t can be implicitly cast to.
+func implicitCastOrder(t string) []string {
+	if order, ok := implicitCast[t]; ok {
+		return order
+	}
+	return []string{t, StringType}
+}
+
+func decodeCustomType(t Type, f *Field, value string) (interface{}, error) {
+	v, err := t.Decode(f, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.CheckConstraints(f, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// ipAddressType is an example of custom type, decoding IP addresses to net.IP.
+type ipAddressType struct{}
+
+func (ipAddressType) Decode(f *Field, value string) (interface{}, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip address:%s", value)
+	}
+	return ip, nil
+}
+
+func (ipAddressType) Encode(f *Field, v interface{}) (string, error) {
+	ip, ok := v.(net.IP)
+	if !ok {
+		return "", fmt.Errorf("invalid ip address:%v", v)
+	}
+	return ip.String(), nil
+}
+
+func (ipAddressType) CheckConstraints(f *Field, v interface{}) error {
+	if f.Format == "ipv4" && v.(net.IP).To4() == nil {
+		return fmt.Errorf("constraint check error: %v is not an ipv4 address", v)
+	}
+	return nil
+}
+
+func (ipAddressType) Infer(value string) bool {
+	return net.ParseIP(value) != nil
+}
+
+const ipAddressTypeName = "ipaddress_test"
+
+// registerTestType registers a type and returns a function which unregisters it.
+func registerTestType(t *testing.T, name string, typ Type) func() {
+	if err := RegisterType(name, typ); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		typesMu.Lock()
+		defer typesMu.Unlock()
+		delete(types, name)
+		for i, n := range typeNames {
+			if n == name {
+				typeNames = append(typeNames[:i], typeNames[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		data := []struct {
+			desc string
+			name string
+			typ  Type
+		}{
+			{"EmptyName", "", ipAddressType{}},
+			{"NilType", "foo", nil},
+			{"BuiltIn", IntegerType, ipAddressType{}},
+			{"AlreadyRegistered", ipAddressTypeName, ipAddressType{}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				is.True(RegisterType(d.name, d.typ) != nil)
+			})
+		}
+	})
+	t.Run("Field", func(t *testing.T) {
+		is := is.New(t)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		f := Field{Name: "ip", Type: ipAddressTypeName}
+		v, err := f.Decode("192.168.0.1")
+		is.NoErr(err)
+		is.True(v.(net.IP).Equal(net.IPv4(192, 168, 0, 1)))
+		_, err = f.Decode("foo")
+		is.True(err != nil)
+
+		got, err := f.Encode(net.IPv4(10, 0, 0, 1))
+		is.NoErr(err)
+		is.Equal(got, "10.0.0.1")
+		_, err = f.Encode(10)
+		is.True(err != nil)
+	})
+	t.Run("Constraints", func(t *testing.T) {
+		is := is.New(t)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		f := Field{Name: "ip", Type: ipAddressTypeName, Format: "ipv4"}
+		_, err := f.Decode("192.168.0.1")
+		is.NoErr(err)
+		_, err = f.Decode("::1")
+		is.True(err != nil)
+	})
+	t.Run("SchemaValidate", func(t *testing.T) {
+		is := is.New(t)
+		s := Schema{Fields: []Field{{Name: "ip", Type: ipAddressTypeName}}}
+		is.True(s.Validate() != nil)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		is.NoErr(s.Validate())
+	})
+	t.Run("Infer", func(t *testing.T) {
+		is := is.New(t)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		s, err := infer([]string{"ip", "count"}, [][]string{{"10.0.0.1", "10"}, {"::1", "20"}})
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, ipAddressTypeName)
+		is.Equal(s.Fields[1].Type, IntegerType)
+	})
+	t.Run("InferImplicitCasting", func(t *testing.T) {
+		is := is.New(t)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		s, err := inferImplicitCasting([]string{"ip", "mixed"}, [][]string{{"10.0.0.1", "10.0.0.1"}, {"::1", "foo"}})
+		is.NoErr(err)
+		is.Equal(s.Fields[0].Type, ipAddressTypeName)
+		is.Equal(s.Fields[1].Type, StringType)
+	})
+	t.Run("Decode", func(t *testing.T) {
+		is := is.New(t)
+		defer registerTestType(t, ipAddressTypeName, ipAddressType{})()
+		s := Schema{Fields: []Field{{Name: "IP", Type: ipAddressTypeName}}}
+		var row struct{ IP net.IP }
+		is.NoErr(s.Decode([]string{"10.0.0.1"}, &row))
+		is.True(row.IP.Equal(net.IPv4(10, 0, 0, 1)))
+	})
+}
@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Year represents a "year" cell.
+// More at: https://specs.frictionlessdata.io/table-schema/#year
+type Year int
+
+// ParseYear parses a year represented as YYYY.
+func ParseYear(value string) (Year, error) {
+	if !yearRegexp.MatchString(value) {
+		return 0, fmt.Errorf("invalid year:\"%s\"", value)
+	}
+	y, _ := strconv.Atoi(value)
+	return Year(y), nil
+}
+
+// String returns the year represented as YYYY.
+func (y Year) String() string {
+	return fmt.Sprintf("%04d", int(y))
+}
+
+// Add returns the year n years after y (or before, if n is negative).
+func (y Year) Add(n int) Year {
+	return y + Year(n)
+}
+
+// Compare returns -1, 0 or 1 if y is respectively before, equal or after o.
+func (y Year) Compare(o Year) int {
+	return compareInts(int(y), int(o))
+}
+
+// Start returns the first instant of the year in the passed-in location.
+func (y Year) Start(loc *time.Location) time.Time {
+	return time.Date(int(y), time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (y Year) MarshalText() ([]byte, error) {
+	return []byte(y.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (y *Year) UnmarshalText(text []byte) error {
+	v, err := ParseYear(string(text))
+	if err != nil {
+		return err
+	}
+	*y = v
+	return nil
+}
+
+// YearMonth represents a "yearmonth" cell.
+// More at: https://specs.frictionlessdata.io/table-schema/#yearmonth
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// ParseYearMonth parses a year and month represented as YYYY-MM.
+func ParseYearMonth(value string) (YearMonth, error) {
+	m := yearMonthRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return YearMonth{}, fmt.Errorf("invalid yearmonth:\"%s\"", value)
+	}
+	y, _ := strconv.Atoi(m[1])
+	month, _ := strconv.Atoi(m[2])
+	if month < 1 || month > 12 {
+		return YearMonth{}, fmt.Errorf("invalid yearmonth:\"%s\" month out of range", value)
+	}
+	return YearMonth{Year: y, Month: time.Month(month)}, nil
+}
+
+// String returns the year and month represented as YYYY-MM.
+func (ym YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", ym.Year, int(ym.Month))
+}
+
+// AddMonths returns the year and month n months after ym (or before, if n is negative).
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	months := ym.Year*12 + int(ym.Month) - 1 + n
+	y, m := months/12, months%12
+	if m < 0 {
+		y, m = y-1, m+12
+	}
+	return YearMonth{Year: y, Month: time.Month(m + 1)}
+}
+
+// Compare returns -1, 0 or 1 if ym is respectively before, equal or after o.
+func (ym YearMonth) Compare(o YearMonth) int {
+	if c := compareInts(ym.Year, o.Year); c != 0 {
+		return c
+	}
+	return compareInts(int(ym.Month), int(o.Month))
+}
+
+// Start returns the first instant of the month in the passed-in location.
+func (ym YearMonth) Start(loc *time.Location) time.Time {
+	return time.Date(ym.Year, ym.Month, 1, 0, 0, 0, 0, loc)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (ym *YearMonth) UnmarshalText(text []byte) error {
+	v, err := ParseYearMonth(string(text))
+	if err != nil {
+		return err
+	}
+	*ym = v
+	return nil
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+var (
+	yearRegexp      = regexp.MustCompile(`^\d{4}$`)
+	yearMonthRegexp = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+)
+
+func decodeYear(value string, c Constraints) (Year, error) {
+	y, err := ParseYear(value)
+	if err != nil {
+		return y, err
+	}
+	return y, checkBounds(y, c, yearOrdering)
+}
+
+func decodeYearMonth(value string, c Constraints) (YearMonth, error) {
+	ym, err := ParseYearMonth(value)
+	if err != nil {
+		return ym, err
+	}
+	return ym, checkBounds(ym, c, yearMonthOrdering)
+}
+
+func encodeYear(in interface{}) (string, error) {
+	switch v := in.(type) {
+	case Year:
+		return v.String(), nil
+	case time.Time:
+		return Year(v.Year()).String(), nil
+	}
+	value := reflect.ValueOf(in)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Year(value.Int()).String(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Year(value.Uint()).String(), nil
+	}
+	return "", fmt.Errorf("invalid year - value:%v type:%v", in, reflect.TypeOf(in))
+}
+
+func encodeYearMonth(in interface{}) (string, error) {
+	switch v := in.(type) {
+	case YearMonth:
+		return v.String(), nil
+	case time.Time:
+		return YearMonth{Year: v.Year(), Month: v.Month()}.String(), nil
+	}
+	return "", fmt.Errorf("invalid yearmonth - value:%v type:%v", in, reflect.TypeOf(in))
+}
@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDecodeYear(t *testing.T) {
+	t.Run("ValidMaximum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeYear("2006", Constraints{Maximum: "2007"})
+		is.NoErr(err)
+	})
+	t.Run("ValidMinimum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeYear("2007", Constraints{Minimum: "2006"})
+		is.NoErr(err)
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc        string
+			year        string
+			constraints Constraints
+		}{
+			{"InvalidYear", "foo", Constraints{}},
+			{"ShortYear", "206", Constraints{}},
+			{"YearBiggerThanMaximum", "2006", Constraints{Maximum: "2005"}},
+			{"InvalidMaximum", "2005", Constraints{Maximum: "boo"}},
+			{"YearSmallerThanMinimum", "2005", Constraints{Minimum: "2006"}},
+			{"InvalidMinimum", "2005", Constraints{Minimum: "boo"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeYear(d.year, d.constraints)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestDecodeYearMonth(t *testing.T) {
+	t.Run("ValidMaximum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeYearMonth("2006-02", Constraints{Maximum: "2006-03"})
+		is.NoErr(err)
+	})
+	t.Run("ValidMinimum", func(t *testing.T) {
+		is := is.New(t)
+		_, err := decodeYearMonth("2006-03", Constraints{Minimum: "2006-02"})
+		is.NoErr(err)
+	})
+	t.Run("Error", func(t *testing.T) {
+		data := []struct {
+			desc        string
+			year        string
+			constraints Constraints
+		}{
+			{"InvalidYear", "foo", Constraints{}},
+			{"InvalidMonth", "2006-13", Constraints{}},
+			{"Date", "2006-02-01", Constraints{}},
+			{"YearBiggerThanMaximum", "2006-02", Constraints{Maximum: "2006-01"}},
+			{"InvalidMaximum", "2005-02", Constraints{Maximum: "boo"}},
+			{"YearSmallerThanMinimum", "2006-02", Constraints{Minimum: "2006-03"}},
+			{"InvalidMinimum", "2005-02", Constraints{Minimum: "boo"}},
+		}
+		for _, d := range data {
+			t.Run(d.desc, func(t *testing.T) {
+				is := is.New(t)
+				_, err := decodeYearMonth(d.year, d.constraints)
+				is.True(err != nil)
+			})
+		}
+	})
+}
+
+func TestYear(t *testing.T) {
+	is := is.New(t)
+	y, err := ParseYear("2017")
+	is.NoErr(err)
+	is.Equal(y, Year(2017))
+	is.Equal(y.String(), "2017")
+	is.Equal(Year(17).String(), "0017")
+	is.Equal(y.Add(-18), Year(1999))
+	is.Equal(y.Compare(2018), -1)
+	is.Equal(y.Compare(2017), 0)
+	is.Equal(y.Start(time.UTC), time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var got struct{ Y Year }
+	is.NoErr(json.Unmarshal([]byte(`{"Y":"2018"}`), &got))
+	is.Equal(got.Y, Year(2018))
+	b, err := json.Marshal(got)
+	is.NoErr(err)
+	is.Equal(string(b), `{"Y":"2018"}`)
+}
+
+func TestYearMonth(t *testing.T) {
+	is := is.New(t)
+	ym, err := ParseYearMonth("2017-05")
+	is.NoErr(err)
+	is.Equal(ym, YearMonth{2017, time.May})
+	is.Equal(ym.String(), "2017-05")
+	is.Equal(ym.AddMonths(8), YearMonth{2018, time.January})
+	is.Equal(ym.AddMonths(-5), YearMonth{2016, time.December})
+	is.Equal(ym.AddMonths(-17), YearMonth{2015, time.December})
+	is.Equal(ym.Compare(YearMonth{2017, time.June}), -1)
+	is.Equal(ym.Compare(YearMonth{2016, time.June}), 1)
+	is.Equal(ym.Compare(YearMonth{2017, time.May}), 0)
+	is.Equal(ym.Start(time.UTC), time.Date(2017, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	var got struct{ YM YearMonth }
+	is.NoErr(json.Unmarshal([]byte(`{"YM":"2018-11"}`), &got))
+	is.Equal(got.YM, YearMonth{2018, time.November})
+	b, err := json.Marshal(got)
+	is.NoErr(err)
+	is.Equal(string(b), `{"YM":"2018-11"}`)
+}
+
+func TestEncodeYear(t *testing.T) {
+	data := []struct {
+		desc  string
+		value interface{}
+		want  string
+	}{
+		{"Year", Year(2017), "2017"},
+		{"Int", 2017, "2017"},
+		{"Uint16", uint16(2017), "2017"},
+		{"Time", time.Date(2017, 5, 1, 0, 0, 0, 0, time.UTC), "2017"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := encodeYear(d.value)
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := encodeYear("2017")
+		is.True(err != nil)
+	})
+}
+
+func TestEncodeYearMonth(t *testing.T) {
+	data := []struct {
+		desc  string
+		value interface{}
+		want  string
+	}{
+		{"YearMonth", YearMonth{2017, time.May}, "2017-05"},
+		{"Time", time.Date(2017, 5, 1, 0, 0, 0, 0, time.UTC), "2017-05"},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			got, err := encodeYearMonth(d.value)
+			is.NoErr(err)
+			is.Equal(got, d.want)
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		is := is.New(t)
+		_, err := encodeYearMonth(201705)
+		is.True(err != nil)
+	})
+}
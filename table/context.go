@@ -0,0 +1,56 @@
+package table
+
+import "context"
+
+// ContextTable is implemented by tables which iteration can be canceled, as the
+// tables of this project do.
+type ContextTable interface {
+	Table
+
+	// IterContext is like Iter, but the iteration stops when the context is done,
+	// Err returning the context error.
+	IterContext(ctx context.Context) (Iterator, error)
+}
+
+// IterContext iterates over the table until the context is done. Tables which do not
+// implement ContextTable have their iterator wrapped by WithContext.
+func IterContext(ctx context.Context, t Table) (Iterator, error) {
+	if ct, ok := t.(ContextTable); ok {
+		return ct.IterContext(ctx)
+	}
+	iter, err := t.Iter()
+	if err != nil {
+		return nil, err
+	}
+	return WithContext(ctx, iter), nil
+}
+
+// WithContext wraps the iterator so it stops when the context is done, which is checked
+// before reading each row. Err then returns the context error.
+func WithContext(ctx context.Context, iter Iterator) Iterator {
+	return &contextIterator{Iterator: iter, ctx: ctx}
+}
+
+type contextIterator struct {
+	Iterator
+	ctx context.Context
+	err error
+}
+
+func (i *contextIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	if err := i.ctx.Err(); err != nil {
+		i.err = err
+		return false
+	}
+	return i.Iterator.Next()
+}
+
+func (i *contextIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.Iterator.Err()
+}
@@ -0,0 +1,51 @@
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// iterTable is a Table which does not implement ContextTable.
+type iterTable struct {
+	t *SliceTable
+}
+
+func (t iterTable) Headers() []string            { return t.t.Headers() }
+func (t iterTable) Iter() (Iterator, error)      { return t.t.Iter() }
+func (t iterTable) ReadAll() ([][]string, error) { return t.t.ReadAll() }
+
+func TestIterContext(t *testing.T) {
+	data := []struct {
+		desc string
+		tab  Table
+	}{
+		{"ContextTable", FromSlices([]string{"name"}, [][]string{{"foo"}, {"bar"}})},
+		{"Table", iterTable{FromSlices([]string{"name"}, [][]string{{"foo"}, {"bar"}})}},
+	}
+	for _, d := range data {
+		t.Run(d.desc, func(t *testing.T) {
+			is := is.New(t)
+			_, isContextTable := d.tab.(ContextTable)
+			is.Equal(isContextTable, d.desc == "ContextTable")
+			ctx, cancel := context.WithCancel(context.Background())
+			iter, err := IterContext(ctx, d.tab)
+			is.NoErr(err)
+			defer iter.Close()
+			is.True(iter.Next()) // want first row
+			is.Equal(iter.Row(), []string{"foo"})
+			cancel()
+			is.True(!iter.Next()) // iteration must stop once canceled
+			is.Equal(iter.Err(), context.Canceled)
+		})
+	}
+	t.Run("NotCanceled", func(t *testing.T) {
+		is := is.New(t)
+		iter, err := IterContext(context.Background(), FromSlices([]string{"name"}, [][]string{{"foo"}}))
+		is.NoErr(err)
+		is.True(iter.Next())  // want one row
+		is.True(!iter.Next()) // more iterations than it should
+		is.NoErr(iter.Err())
+	})
+}
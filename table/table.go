@@ -18,6 +18,7 @@ package table
 
 import (
 	"bytes"
+	"context"
 	"encoding/csv"
 )
 
@@ -100,6 +101,11 @@ func (t *SliceTable) Iter() (Iterator, error) {
 	return &sliceIterator{content: t.content}, nil
 }
 
+// IterContext is like Iter, but the iteration stops when the context is done.
+func (t *SliceTable) IterContext(ctx context.Context) (Iterator, error) {
+	return WithContext(ctx, &sliceIterator{content: t.content}), nil
+}
+
 type sliceIterator struct {
 	content [][]string
 	pos     int